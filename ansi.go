@@ -0,0 +1,101 @@
+package solver
+
+import (
+	"io"
+	"strings"
+)
+
+// ANSI escape sequences used by ANSIRenderer
+const (
+	ansiReset      = "\x1b[0m"
+	ansiGiven      = "\x1b[1m"
+	ansiSolved     = "\x1b[34m"
+	ansiTouched    = "\x1b[43m"
+	ansiCandidate  = "\x1b[2m"
+	ansiHighlight  = "\x1b[32m"
+	ansiEliminated = "\x1b[9;31m"
+)
+
+// ANSIRenderer renders the sudoku for terminals with colored givens, solved
+// values and highlights. Candidates are rendered in the pencil mark layout
+// (see WritePencilMarks), otherwise the layout of Print is used.
+type ANSIRenderer struct {
+	Options    RenderOptions
+	Candidates bool
+	Highlights *Highlights
+}
+
+func ansi(code string, text string) string {
+	return code + text + ansiReset
+}
+
+func (r ANSIRenderer) valueStyle(s *Solver, row int, col int) string {
+	style := ""
+	if s.Problem.Sudoku[row][col] != 0 {
+		if isGiven(s, row, col) {
+			style = ansiGiven
+		} else {
+			style = ansiSolved
+		}
+	}
+	if r.Highlights.hasCell(row, col) {
+		style += ansiTouched
+	}
+	return style
+}
+
+func (r ANSIRenderer) Render(w io.Writer, s *Solver) error {
+	o := r.Options.withDefaults(s)
+
+	if !r.Candidates {
+		return renderCompact(w, s, o, func(row int, col int, padded string) string {
+			if style := r.valueStyle(s, row, col); style != "" {
+				return ansi(style, padded) + " "
+			}
+			return padded + " "
+		})
+	}
+
+	text := func(row int, col int) string {
+		if value := s.Problem.Sudoku[row][col]; value != 0 {
+			return string(o.Symbols.Symbol(value))
+		}
+		candidates := r.Highlights.cellCandidates(s, row, col)
+		if len(candidates) == 0 {
+			return o.NullValue
+		}
+		var sb strings.Builder
+		for _, candidate := range candidates {
+			sb.WriteRune(o.Symbols.Symbol(candidate))
+		}
+		return sb.String()
+	}
+
+	return writeCandidateGrid(w, s, text, func(row int, col int, padded string) string {
+		style := r.valueStyle(s, row, col)
+		if s.Problem.Sudoku[row][col] != 0 {
+			return ansi(style, padded)
+		}
+
+		var sb strings.Builder
+		sb.WriteString(style)
+		for _, ch := range padded {
+			value, ok := o.Symbols.Value(ch)
+			switch {
+			case !ok:
+				sb.WriteRune(ch)
+				continue
+			case r.Highlights.isEliminated(row, col, value):
+				sb.WriteString(ansiEliminated)
+			case r.Highlights.hasCandidate(row, col, value):
+				sb.WriteString(ansiHighlight)
+			default:
+				sb.WriteString(ansiCandidate)
+			}
+			sb.WriteRune(ch)
+			sb.WriteString(ansiReset + style)
+		}
+		sb.WriteString(ansiReset)
+		return sb.String()
+	})
+}
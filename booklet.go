@@ -0,0 +1,241 @@
+package solver
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// page sizes in points
+const (
+	A4Width      = 595.0
+	A4Height     = 842.0
+	LetterWidth  = 612.0
+	LetterHeight = 792.0
+)
+
+type BookletOptions struct {
+	PageWidth     float64 // A4 when 0
+	PageHeight    float64
+	Margin        float64 // 36 points when 0
+	Title         string  // printed on top of every page
+	Columns       int     // puzzles per page are Columns x Rows, 1 x 2 when 0
+	Rows          int
+	AnswerColumns int // answers per page are AnswerColumns x AnswerRows, 2 x 3 when 0
+	AnswerRows    int
+	Symbols       *SymbolSet // default symbol set of the sudoku size when nil
+}
+
+func (o BookletOptions) withDefaults() BookletOptions {
+	if (o.PageWidth <= 0) || (o.PageHeight <= 0) {
+		o.PageWidth, o.PageHeight = A4Width, A4Height
+	}
+	if o.Margin <= 0 {
+		o.Margin = 36
+	}
+	if (o.Columns <= 0) || (o.Rows <= 0) {
+		o.Columns, o.Rows = 1, 2
+	}
+	if (o.AnswerColumns <= 0) || (o.AnswerRows <= 0) {
+		o.AnswerColumns, o.AnswerRows = 2, 3
+	}
+	return o
+}
+
+func copyMatrix(m *SudokuMatrix) SudokuMatrix {
+	c := SudokuMatrix{Sudoku: make([][]int, len(m.Sudoku))}
+	for r, row := range m.Sudoku {
+		c.Sudoku[r] = append([]int(nil), row...)
+	}
+	return c
+}
+
+// solveCopy solves a copy of the puzzle, the puzzle is unchanged
+func solveCopy(m *SudokuMatrix) (*Solver, error) {
+	puzzle := copyMatrix(m)
+	s, err := CheckSudoku(&puzzle)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = Solve(s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// WriteBooklet writes a PDF with the puzzles followed by pages with answers
+func WriteBooklet(w io.Writer, puzzles []SudokuMatrix, options BookletOptions) error {
+	o := options.withDefaults()
+
+	answers := make([]*Solver, len(puzzles))
+	for i := range puzzles {
+		answer, err := solveCopy(&puzzles[i])
+		if err != nil {
+			return fmt.Errorf("ERROR: Puzzle %v: %w", i+1, err)
+		}
+		answers[i] = answer
+	}
+
+	pages := make([]string, 0)
+	perPage := o.Columns * o.Rows
+	for first := 0; first < len(puzzles); first += perPage {
+		var sb strings.Builder
+		pageHeader(&sb, &o, o.Title)
+		for i := first; (i < first+perPage) && (i < len(puzzles)); i++ {
+			s, _ := CheckSudoku(&puzzles[i])
+			drawPuzzle(&sb, &o, s, i-first, o.Columns, o.Rows, fmt.Sprintf("%v", i+1), false)
+		}
+		pages = append(pages, sb.String())
+	}
+
+	perPage = o.AnswerColumns * o.AnswerRows
+	for first := 0; first < len(answers); first += perPage {
+		var sb strings.Builder
+		title := "Answers"
+		if o.Title != "" {
+			title = o.Title + " - " + title
+		}
+		pageHeader(&sb, &o, title)
+		for i := first; (i < first+perPage) && (i < len(answers)); i++ {
+			drawPuzzle(&sb, &o, answers[i], i-first, o.AnswerColumns, o.AnswerRows, fmt.Sprintf("%v", i+1), true)
+		}
+		pages = append(pages, sb.String())
+	}
+
+	return writePDF(w, &o, pages)
+}
+
+const titleSize = 16.0
+const labelSize = 11.0
+
+func pageHeader(sb *strings.Builder, o *BookletOptions, title string) {
+	if title == "" {
+		return
+	}
+	pdfText(sb, "F2", titleSize, o.PageWidth/2, o.PageHeight-o.Margin-titleSize/2, title)
+}
+
+// drawPuzzle draws the sudoku in the slot of a page divided in columns x rows
+func drawPuzzle(sb *strings.Builder, o *BookletOptions, s *Solver, slot int, columns int, rows int, label string, answer bool) {
+	top := o.PageHeight - o.Margin
+	if o.Title != "" || answer {
+		top -= 2 * titleSize
+	}
+	slotWidth := (o.PageWidth - 2*o.Margin) / float64(columns)
+	slotHeight := (top - o.Margin) / float64(rows)
+
+	size := min(slotWidth, slotHeight-2*labelSize) * 0.9
+	cell := size / float64(s.Length)
+	x := o.Margin + float64(slot%columns)*slotWidth + (slotWidth-size)/2
+	y := top - float64(slot/columns)*slotHeight - 2*labelSize // top of the grid
+
+	pdfText(sb, "F2", labelSize, x+labelSize/2, y+labelSize*0.8, label)
+
+	for r, row := range s.Problem.Sudoku {
+		for c, value := range row {
+			if value == 0 {
+				continue
+			}
+			font := "F1"
+			if answer && isGiven(s, r, c) {
+				font = "F2"
+			}
+			pdfText(sb, font, cell*0.6, x+(float64(c)+0.5)*cell, y-(float64(r)+0.5)*cell, string(symbolsOrDefault(o.Symbols, s.Length).Symbol(value)))
+		}
+	}
+
+	for i := 0; i <= s.Length; i++ {
+		pos := float64(i) * cell
+		width := 0.5
+		if (i % s.BoxWidth) == 0 {
+			width = 2
+		}
+		fmt.Fprintf(sb, "%.2f w %.2f %.2f m %.2f %.2f l S\n", width, x+pos, y, x+pos, y-size)
+		width = 0.5
+		if (i % s.BoxHeight) == 0 {
+			width = 2
+		}
+		fmt.Fprintf(sb, "%.2f w %.2f %.2f m %.2f %.2f l S\n", width, x, y-pos, x+size, y-pos)
+	}
+}
+
+// Helvetica glyph widths in 1/1000 of the font size
+var helveticaWidths = map[rune]int{
+	'A': 667, 'B': 667, 'C': 722, 'D': 722, 'E': 667, 'F': 611, 'G': 778, 'H': 722, 'I': 278,
+	'J': 500, 'K': 667, 'L': 556, 'M': 833, 'N': 722, 'O': 778, 'P': 667, 'Q': 778, 'R': 722,
+	'S': 667, 'T': 611, 'U': 722, 'V': 667, 'W': 944, 'X': 667, 'Y': 667, 'Z': 611, ' ': 278,
+}
+
+func textWidth(text string, size float64) float64 {
+	width := 0
+	for _, ch := range text {
+		if w, ok := helveticaWidths[ch]; ok {
+			width += w
+		} else {
+			width += 556 // digits and the rest
+		}
+	}
+	return float64(width) * size / 1000
+}
+
+// pdfText draws the text centered at x, y
+func pdfText(sb *strings.Builder, font string, size float64, x float64, y float64, text string) {
+	escaped := strings.NewReplacer(`\`, `\\`, "(", `\(`, ")", `\)`).Replace(text)
+	fmt.Fprintf(sb, "BT /%v %.2f Tf %.2f %.2f Td (%v) Tj ET\n", font, size, x-textWidth(text, size)/2, y-size*0.36, escaped)
+}
+
+// writePDF writes a PDF document with pages drawn by the content streams
+func writePDF(w io.Writer, o *BookletOptions, pages []string) error {
+	bw := bufio.NewWriter(w)
+	cw := &countingWriter{w: bw}
+	offsets := make(map[int]int)
+	object := func(number int, body string) {
+		offsets[number] = cw.n
+		fmt.Fprintf(cw, "%v 0 obj\n%v\nendobj\n", number, body)
+	}
+
+	// 1 catalog, 2 pages, 3 and 4 fonts, then pairs of page and content objects
+	fmt.Fprintf(cw, "%%PDF-1.4\n")
+	object(1, "<< /Type /Catalog /Pages 2 0 R >>")
+	object(3, "<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object(4, "<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+
+	kids := make([]string, len(pages))
+	for i, content := range pages {
+		page := 5 + 2*i
+		kids[i] = fmt.Sprintf("%v 0 R", page)
+		object(page, fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %v 0 R >>",
+			o.PageWidth, o.PageHeight, page+1))
+		object(page+1, fmt.Sprintf("<< /Length %v >>\nstream\n%vendstream", len(content), content))
+	}
+	object(2, fmt.Sprintf("<< /Type /Pages /Kids [%v] /Count %v >>", strings.Join(kids, " "), len(pages)))
+
+	xref := cw.n
+	count := 5 + 2*len(pages)
+	fmt.Fprintf(cw, "xref\n0 %v\n0000000000 65535 f \n", count)
+	for i := 1; i < count; i++ {
+		fmt.Fprintf(cw, "%010d 00000 n \n", offsets[i])
+	}
+	fmt.Fprintf(cw, "trailer\n<< /Size %v /Root 1 0 R >>\nstartxref\n%v\n%%%%EOF\n", count, xref)
+	if cw.err != nil {
+		return cw.err
+	}
+	return bw.Flush()
+}
+
+type countingWriter struct {
+	w   io.Writer
+	n   int
+	err error
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	if cw.err != nil {
+		return 0, cw.err
+	}
+	n, err := cw.w.Write(p)
+	cw.n += n
+	cw.err = err
+	return n, err
+}
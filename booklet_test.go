@@ -0,0 +1,48 @@
+package solver
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestBooklet1(t *testing.T) {
+	lines := []string{
+		"8....7.9..29..4..63..2..........65...174...3.2.........941...7...8..........7...3",
+		"47.3..218.824.17.313..8..45.1....3..6.3.154..74..3....8.1...539..75..1.4.541...7.",
+		"..3.2.6..9..3.5..1..18.64....81.29..7.......8..67.82....26.95..8..2.3..9..5.1.3..",
+		"1.....2..3.....4",
+		strings.Repeat(".", 256),
+	}
+	puzzles := make([]SudokuMatrix, len(lines))
+	for i, line := range lines {
+		m, err := ParseLine(line)
+		if err != nil {
+			t.Fatalf("error: %v\n", err)
+		}
+		puzzles[i] = *m
+	}
+
+	var buf bytes.Buffer
+	if err := WriteBooklet(&buf, puzzles, BookletOptions{Title: "Test"}); err != nil {
+		t.Fatalf("error: %v\n", err)
+	}
+	pdf := buf.String()
+	if !strings.HasPrefix(pdf, "%PDF-1.4") || !strings.HasSuffix(pdf, "%%EOF\n") {
+		t.Errorf("invalid pdf")
+	}
+	if !strings.Contains(pdf, "/Count 4") { // 3 pages with puzzles, 1 with answers
+		t.Errorf("expected 4 pages")
+	}
+	if FormatLine(&puzzles[0]) != lines[0] {
+		t.Errorf("puzzle changed while solving")
+	}
+}
+
+func TestBooklet2(t *testing.T) {
+	m, _ := ParseLine("11..............")
+	var buf bytes.Buffer
+	if err := WriteBooklet(&buf, []SudokuMatrix{*m}, BookletOptions{}); err == nil {
+		t.Errorf("expected error on invalid puzzle")
+	}
+}
@@ -0,0 +1,125 @@
+package solver
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Budget limits the work of the solver, zero values are unlimited
+type Budget struct {
+	MaxNodes      int // search nodes of Depth First Search or Dancing Links
+	MaxDepth      int // depth of the search, the root is depth 1
+	MaxIterations int // rounds of the strategies
+}
+
+// SolveStats is the progress of the solver when it was interrupted
+type SolveStats struct {
+	Iterations int // rounds of the strategies
+	Nodes      int // search nodes
+	Depth      int // deepest search level reached
+	Empty      int // empty cells when the solver stopped
+	Elapsed    time.Duration
+}
+
+// InterruptedError is returned when the context is done or the budget is
+// exhausted. Err is the context error or ErrBudgetExhausted, so errors.Is
+// matches context.Canceled, context.DeadlineExceeded or ErrBudgetExhausted.
+type InterruptedError struct {
+	Err   error
+	Limit string // exhausted limit of the budget
+	Stats SolveStats
+}
+
+func (e *InterruptedError) Error() string {
+	if e.Limit != "" {
+		return fmt.Sprintf("%v (%v)", e.Err, e.Limit)
+	}
+	return fmt.Sprintf("ERROR: Solver interrupted: %v", e.Err)
+}
+
+func (e *InterruptedError) Unwrap() error {
+	return e.Err
+}
+
+// the context is checked every contextCheckNodes search nodes
+const contextCheckNodes = 256
+
+// control checks the context and the budget while solving, a nil control is
+// unlimited
+type control struct {
+	ctx    context.Context
+	budget Budget
+	start  time.Time
+	stats  SolveStats
+	err    *InterruptedError
+}
+
+func newControl(ctx context.Context, budget Budget) *control {
+	return &control{ctx: ctx, budget: budget, start: time.Now()}
+}
+
+func (c *control) interrupt(err error, limit string) bool {
+	c.err = &InterruptedError{Err: err, Limit: limit}
+	return false
+}
+
+// node counts a search node at the depth, it returns false without counting
+// when the solver has to stop
+func (c *control) node(depth int) bool {
+	if c == nil {
+		return true
+	}
+	if c.err != nil {
+		return false
+	}
+	if (c.budget.MaxNodes > 0) && (c.stats.Nodes >= c.budget.MaxNodes) {
+		return c.interrupt(ErrBudgetExhausted, fmt.Sprintf("max nodes %v", c.budget.MaxNodes))
+	}
+	if (c.budget.MaxDepth > 0) && (depth > c.budget.MaxDepth) {
+		return c.interrupt(ErrBudgetExhausted, fmt.Sprintf("max depth %v", c.budget.MaxDepth))
+	}
+	if (c.stats.Nodes%contextCheckNodes == 0) && (c.ctx.Err() != nil) {
+		return c.interrupt(c.ctx.Err(), "")
+	}
+	c.stats.Nodes++
+	c.stats.Depth = max(c.stats.Depth, depth)
+	return true
+}
+
+// iteration counts a round of the strategies, it returns false without
+// counting when the solver has to stop
+func (c *control) iteration() bool {
+	if c == nil {
+		return true
+	}
+	if c.err != nil {
+		return false
+	}
+	if (c.budget.MaxIterations > 0) && (c.stats.Iterations >= c.budget.MaxIterations) {
+		return c.interrupt(ErrBudgetExhausted, fmt.Sprintf("max iterations %v", c.budget.MaxIterations))
+	}
+	if c.ctx.Err() != nil {
+		return c.interrupt(c.ctx.Err(), "")
+	}
+	c.stats.Iterations++
+	return true
+}
+
+// error returns the *InterruptedError with the statistics of the solver,
+// nil when the solver was not interrupted
+func (c *control) error(s *Solver) error {
+	if (c == nil) || (c.err == nil) {
+		return nil
+	}
+	c.stats.Elapsed = time.Since(c.start)
+	for _, row := range s.Problem.Sudoku {
+		for _, value := range row {
+			if value == 0 {
+				c.stats.Empty++
+			}
+		}
+	}
+	c.err.Stats = c.stats
+	return c.err
+}
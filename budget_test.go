@@ -0,0 +1,95 @@
+package solver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+const hardSudoku = "8..........36......7..9.2...5...7.......457.....1...3...1....68..85...1..9....4.."
+
+func TestBudget1(t *testing.T) {
+	backends := []Backend{BackendStrategies, BackendDepthFirstSearch, BackendDLX}
+	for i, backend := range backends {
+		m, _ := ParseLine(hardSudoku)
+		s, _ := CheckSudoku(m)
+		solved, err := SolveWithOptions(s, SolveOptions{Backend: backend, Budget: Budget{MaxNodes: 10}})
+		fmt.Printf("test %v: %v\n", i, err)
+		if solved || !errors.Is(err, ErrBudgetExhausted) {
+			t.Fatalf("test %v: expected ErrBudgetExhausted, got %v", i, err)
+		}
+		var interrupted *InterruptedError
+		if !errors.As(err, &interrupted) {
+			t.Fatalf("test %v: expected *InterruptedError, got %T", i, err)
+		}
+		if interrupted.Stats.Nodes != 10 {
+			t.Errorf("test %v: expected 10 nodes, got %v", i, interrupted.Stats.Nodes)
+		}
+		if interrupted.Stats.Empty == 0 {
+			t.Errorf("test %v: expected empty cells", i)
+		}
+		for r, row := range s.Problem.Sudoku {
+			for c, value := range row {
+				if isGiven(s, r, c) && (value != m.Sudoku[r][c]) {
+					t.Fatalf("test %v: given changed in [%v, %v]", i, r, c)
+				}
+			}
+		}
+	}
+}
+
+func TestBudget2(t *testing.T) {
+	var interrupted *InterruptedError
+	for i, backend := range []Backend{BackendDepthFirstSearch, BackendDLX} {
+		m, _ := ParseLine(hardSudoku)
+		s, _ := CheckSudoku(m)
+		_, err := SolveWithOptions(s, SolveOptions{Backend: backend, Budget: Budget{MaxDepth: 5}})
+		if !errors.Is(err, ErrBudgetExhausted) || !errors.As(err, &interrupted) {
+			t.Fatalf("test %v: expected ErrBudgetExhausted, got %v", i, err)
+		}
+		if interrupted.Stats.Depth != 5 {
+			t.Errorf("test %v: expected depth 5, got %v", i, interrupted.Stats.Depth)
+		}
+	}
+
+	// the root is depth 1 for both backends
+	for i, backend := range []Backend{BackendDepthFirstSearch, BackendDLX} {
+		m, _ := ParseLine(hardSudoku)
+		s, _ := CheckSudoku(m)
+		_, err := SolveWithOptions(s, SolveOptions{Backend: backend, Budget: Budget{MaxNodes: 1}})
+		if !errors.As(err, &interrupted) || (interrupted.Stats.Depth != 1) {
+			t.Errorf("test %v: expected depth 1, got %v", i, err)
+		}
+	}
+
+	// the strategies need several rounds
+	m, _ := ParseLine(".1...3.6.....9.2..548.6.......9...2...7...5..1...5........8.45...9.4.8...2.6...93")
+	s, _ := CheckSudoku(m)
+	_, err := SolveWithOptions(s, SolveOptions{Budget: Budget{MaxIterations: 1}})
+	if !errors.Is(err, ErrBudgetExhausted) || !errors.As(err, &interrupted) {
+		t.Fatalf("expected ErrBudgetExhausted, got %v", err)
+	}
+	if interrupted.Stats.Iterations != 1 {
+		t.Errorf("expected 1 iteration, got %v", interrupted.Stats.Iterations)
+	}
+}
+
+func TestBudget3(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	m, _ := ParseLine(hardSudoku)
+	s, _ := CheckSudoku(m)
+	solved, err := SolveContext(ctx, s, SolveOptions{})
+	if solved || !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+
+	// without limits the context only stops the solver when it is done
+	m, _ = ParseLine(hardSudoku)
+	s, _ = CheckSudoku(m)
+	solved, err = SolveContext(context.Background(), s, SolveOptions{Backend: BackendDLX, Budget: Budget{MaxNodes: 1000000}})
+	if err != nil || !solved {
+		t.Fatalf("sudoku not solved: %v", err)
+	}
+}
@@ -0,0 +1,144 @@
+package solver
+
+import (
+	"fmt"
+	"math/bits"
+	"strings"
+)
+
+// MaxLength is the largest supported sudoku (64x64), the candidates of a cell
+// are the bits of CandidateSet
+const MaxLength = 64
+
+// CandidateSet is the set of candidates of a cell, bit v-1 is set for the
+// candidate v. The zero value is the empty set.
+type CandidateSet uint64
+
+func NewCandidateSet(values ...int) CandidateSet {
+	var cs CandidateSet
+	for _, value := range values {
+		cs = cs.Add(value)
+	}
+	return cs
+}
+
+// AllCandidates returns the set of candidates 1..length
+func AllCandidates(length int) CandidateSet {
+	if length >= MaxLength {
+		return ^CandidateSet(0)
+	}
+	return CandidateSet(1)<<length - 1
+}
+
+func (cs CandidateSet) Has(value int) bool {
+	return (value >= 1) && (value <= MaxLength) && (cs&(1<<(value-1)) != 0)
+}
+
+// Add returns the set with the candidate, values outside 1..MaxLength are ignored
+func (cs CandidateSet) Add(value int) CandidateSet {
+	if (value < 1) || (value > MaxLength) {
+		return cs
+	}
+	return cs | 1<<(value-1)
+}
+
+func (cs CandidateSet) Remove(value int) CandidateSet {
+	if (value < 1) || (value > MaxLength) {
+		return cs
+	}
+	return cs &^ (1 << (value - 1))
+}
+
+// Count returns the number of candidates
+func (cs CandidateSet) Count() int {
+	return bits.OnesCount64(uint64(cs))
+}
+
+func (cs CandidateSet) Union(other CandidateSet) CandidateSet {
+	return cs | other
+}
+
+func (cs CandidateSet) Intersect(other CandidateSet) CandidateSet {
+	return cs & other
+}
+
+// Minus returns the candidates of cs that are not in other
+func (cs CandidateSet) Minus(other CandidateSet) CandidateSet {
+	return cs &^ other
+}
+
+// Min returns the smallest candidate, 0 for the empty set
+func (cs CandidateSet) Min() int {
+	if cs == 0 {
+		return 0
+	}
+	return bits.TrailingZeros64(uint64(cs)) + 1
+}
+
+// Next returns the smallest candidate greater than value, 0 when there is none.
+// The candidates are iterated with: for v := cs.Min(); v != 0; v = cs.Next(v)
+func (cs CandidateSet) Next(value int) int {
+	if value >= MaxLength {
+		return 0
+	}
+	return (cs &^ (CandidateSet(1)<<value - 1)).Min()
+}
+
+// Values returns the candidates in ascending order
+func (cs CandidateSet) Values() []int {
+	values := make([]int, 0, cs.Count())
+	for rest := cs; rest != 0; rest &= rest - 1 {
+		values = append(values, bits.TrailingZeros64(uint64(rest))+1)
+	}
+	return values
+}
+
+func (cs CandidateSet) String() string {
+	values := cs.Values()
+	texts := make([]string, len(values))
+	for i, value := range values {
+		texts[i] = fmt.Sprint(value)
+	}
+	return "[" + strings.Join(texts, " ") + "]"
+}
+
+func newCandidateSets(length int) [][]CandidateSet {
+	candidates := make([][]CandidateSet, length)
+	for r := range candidates {
+		candidates[r] = make([]CandidateSet, length)
+	}
+	return candidates
+}
+
+// CandidateSlices returns the candidates of every cell as sorted slices, the
+// representation of Solver.Candidates before candidate sets. The slices are a
+// copy, nil when the candidates are not set.
+func (s *Solver) CandidateSlices() [][][]int {
+	if s.Candidates == nil {
+		return nil
+	}
+	candidates := make([][][]int, len(s.Candidates))
+	for r, row := range s.Candidates {
+		candidates[r] = make([][]int, len(row))
+		for c, cs := range row {
+			candidates[r][c] = cs.Values()
+		}
+	}
+	return candidates
+}
+
+// SetCandidateSlices sets the candidates from sorted slices (see CandidateSlices),
+// values outside 1..MaxLength are ignored
+func (s *Solver) SetCandidateSlices(candidates [][][]int) {
+	if candidates == nil {
+		s.Candidates = nil
+		return
+	}
+	s.Candidates = make([][]CandidateSet, len(candidates))
+	for r, row := range candidates {
+		s.Candidates[r] = make([]CandidateSet, len(row))
+		for c, values := range row {
+			s.Candidates[r][c] = NewCandidateSet(values...)
+		}
+	}
+}
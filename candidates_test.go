@@ -0,0 +1,93 @@
+package solver
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestCandidateSet1(t *testing.T) {
+	cs := NewCandidateSet(3, 1, 25)
+	if cs.Count() != 3 || !cs.Has(1) || cs.Has(2) || !cs.Has(25) || cs.Has(0) || cs.Has(65) {
+		t.Errorf("wrong candidate set %v", cs)
+	}
+	if cs.Min() != 1 || CandidateSet(0).Min() != 0 {
+		t.Errorf("wrong min %v", cs.Min())
+	}
+	values := make([]int, 0)
+	for v := cs.Min(); v != 0; v = cs.Next(v) {
+		values = append(values, v)
+	}
+	if !reflect.DeepEqual(values, []int{1, 3, 25}) || !reflect.DeepEqual(cs.Values(), values) {
+		t.Errorf("wrong values %v %v", values, cs.Values())
+	}
+	if cs.String() != "[1 3 25]" {
+		t.Errorf("wrong text %v", cs.String())
+	}
+
+	other := NewCandidateSet(3, 4)
+	if cs.Union(other) != NewCandidateSet(1, 3, 4, 25) || cs.Intersect(other) != NewCandidateSet(3) || cs.Minus(other) != NewCandidateSet(1, 25) {
+		t.Errorf("wrong set operations")
+	}
+	if cs.Remove(3) != NewCandidateSet(1, 25) || cs.Add(0) != cs || cs.Add(MaxLength+1) != cs {
+		t.Errorf("wrong add or remove")
+	}
+	if AllCandidates(9).Count() != 9 || AllCandidates(MaxLength).Count() != MaxLength || !AllCandidates(MaxLength).Has(MaxLength) {
+		t.Errorf("wrong all candidates")
+	}
+	if AllCandidates(MaxLength).Next(MaxLength) != 0 {
+		t.Errorf("wrong next after the largest candidate")
+	}
+}
+
+func TestCandidateSet2(t *testing.T) {
+	m, _ := ParseLine("1.....2..3.....4")
+	s, _ := CheckSudoku(m)
+	if s.CandidateSlices() != nil {
+		t.Errorf("expected nil slices")
+	}
+	UpdateAllCandidates(s)
+
+	slices := s.CandidateSlices()
+	if !reflect.DeepEqual(slices[0][1], []int{2, 4}) || len(slices[0][0]) != 0 {
+		t.Errorf("wrong slices %v", slices)
+	}
+	slices[0][1] = []int{4}
+	s.SetCandidateSlices(slices)
+	if s.Candidates[0][1] != NewCandidateSet(4) || s.Candidates[1][0] != NewCandidateSet(3, 4) {
+		t.Errorf("wrong candidates %v", s.Candidates)
+	}
+}
+
+func TestCandidateSet3(t *testing.T) {
+	// 25x25 from the pattern solution with every other cell removed
+	m := SudokuMatrix{Sudoku: make([][]int, 25)}
+	for r := range m.Sudoku {
+		m.Sudoku[r] = make([]int, 25)
+		for c := range m.Sudoku[r] {
+			if (r+c)%2 == 0 {
+				m.Sudoku[r][c] = (5*(r%5)+r/5+c)%25 + 1
+			}
+		}
+	}
+	s, err := CheckSudoku(&m)
+	if err != nil {
+		t.Fatalf("error: %v\n", err)
+	}
+	solved, err := Solve(s)
+	if err != nil || !solved {
+		t.Fatalf("sudoku not solved: %v", err)
+	}
+	if err = Validate(&s.Problem); err != nil {
+		t.Errorf("sudoku not valid: %v", err)
+	}
+
+	large := SudokuMatrix{Sudoku: make([][]int, 81)}
+	for r := range large.Sudoku {
+		large.Sudoku[r] = make([]int, 81)
+	}
+	var v *Violation
+	if _, err = CheckSudoku(&large); !errors.As(err, &v) || v.Kind != ViolationDimension {
+		t.Errorf("expected dimension violation, got %v", err)
+	}
+}
@@ -0,0 +1,98 @@
+package solver
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const CommentPrefix = "#"
+
+// Record is a single puzzle of a collection file with the fields following
+// the puzzle on the same line (rating, source, ...) and the trailing comment.
+type Record struct {
+	Puzzle  SudokuMatrix
+	Meta    []string
+	Comment string
+	Line    int
+}
+
+// CollectionReader reads collection files with one puzzle per line (.sdm and
+// similar). Empty lines and lines starting with '#' are skipped. The symbol
+// set is detected for every puzzle when Symbols is nil.
+type CollectionReader struct {
+	Symbols *SymbolSet
+	scanner *bufio.Scanner
+	line    int
+}
+
+func NewCollectionReader(r io.Reader) *CollectionReader {
+	return &CollectionReader{scanner: bufio.NewScanner(r)}
+}
+
+// Read returns the next record or io.EOF when there are no more records
+func (cr *CollectionReader) Read() (*Record, error) {
+	for cr.scanner.Scan() {
+		cr.line++
+		text := strings.TrimSpace(cr.scanner.Text())
+		if text == "" || strings.HasPrefix(text, CommentPrefix) {
+			continue
+		}
+
+		rec := Record{Line: cr.line}
+		if i := strings.Index(text, CommentPrefix); i >= 0 {
+			rec.Comment = strings.TrimSpace(text[i+1:])
+			text = text[:i]
+		}
+		fields := strings.Fields(text)
+
+		m, err := ParseLineSymbols(fields[0], cr.Symbols)
+		if err != nil {
+			var perr *ParseError
+			if errors.As(err, &perr) {
+				perr.Line = cr.line
+			}
+			return nil, err
+		}
+		rec.Puzzle = *m
+		rec.Meta = fields[1:]
+		return &rec, nil
+	}
+	if err := cr.scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, io.EOF
+}
+
+// CollectionWriter writes records in the format read by CollectionReader,
+// the default symbol set is used when Symbols is nil
+type CollectionWriter struct {
+	Symbols *SymbolSet
+	w       io.Writer
+}
+
+func NewCollectionWriter(w io.Writer) *CollectionWriter {
+	return &CollectionWriter{w: w}
+}
+
+func (cw *CollectionWriter) Write(rec *Record) error {
+	var sb strings.Builder
+	sb.WriteString(FormatLineSymbols(&rec.Puzzle, cw.Symbols))
+	for _, field := range rec.Meta {
+		sb.WriteString(" ")
+		sb.WriteString(field)
+	}
+	if rec.Comment != "" {
+		sb.WriteString(" " + CommentPrefix + " " + rec.Comment)
+	}
+	sb.WriteString("\n")
+	_, err := io.WriteString(cw.w, sb.String())
+	return err
+}
+
+func (cw *CollectionWriter) WriteComment(comment string) error {
+	_, err := fmt.Fprintf(cw.w, "%v %v\n", CommentPrefix, comment)
+	return err
+}
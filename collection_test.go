@@ -0,0 +1,84 @@
+package solver
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestCollection1(t *testing.T) {
+	f, err := os.Open("testdata/puzzles.sdm")
+	if err != nil {
+		t.Fatalf("error: %v\n", err)
+	}
+	defer f.Close()
+
+	cr := NewCollectionReader(f)
+	count := 0
+	for {
+		rec, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("error: %v\n", err)
+		}
+		count++
+
+		v, err := CheckSudoku(&rec.Puzzle)
+		if err != nil {
+			t.Errorf("line %v: error: %v\n", rec.Line, err)
+			continue
+		}
+		fmt.Printf("line %v %v:\n", rec.Line, rec.Meta)
+		solved, err := Solve(v)
+		if err != nil {
+			t.Errorf("line %v: error: %v\n", rec.Line, err)
+			continue
+		}
+		Print(v)
+		if _, err = CheckSudoku(&v.Problem); err != nil || !solved {
+			t.Errorf("line %v: sudoku not solved", rec.Line)
+		}
+	}
+	if count != 5 {
+		t.Errorf("expected 5 records, got %v", count)
+	}
+}
+
+func TestCollection2(t *testing.T) {
+	input := "# comment\n\n1.....2..3.....4 easy test # note\n"
+	rec, err := NewCollectionReader(strings.NewReader(input)).Read()
+	if err != nil {
+		t.Fatalf("error: %v\n", err)
+	}
+	if rec.Line != 3 || len(rec.Meta) != 2 || rec.Meta[1] != "test" || rec.Comment != "note" {
+		t.Errorf("wrong record: %+v", rec)
+	}
+
+	var sb strings.Builder
+	cw := NewCollectionWriter(&sb)
+	cw.WriteComment("comment")
+	cw.Write(rec)
+	expected := "# comment\n1.....2..3.....4 easy test # note\n"
+	if sb.String() != expected {
+		t.Errorf("expected %q, got %q", expected, sb.String())
+	}
+}
+
+func TestCollection3(t *testing.T) {
+	input := "1.....2..3.....4\n1.....2..3....x4\n"
+	cr := NewCollectionReader(strings.NewReader(input))
+	if _, err := cr.Read(); err != nil {
+		t.Fatalf("error: %v\n", err)
+	}
+	_, err := cr.Read()
+	fmt.Println(err)
+	var perr *ParseError
+	if !errors.As(err, &perr) || perr.Line != 2 || perr.Pos != 14 {
+		t.Errorf("expected parse error in line 2, got %v", err)
+	}
+}
@@ -0,0 +1,234 @@
+package solver
+
+// Dancing Links (Algorithm X) solver. The sudoku is an exact cover problem:
+// every cell has one value and every row, col and block has every value once.
+// Constraints satisfied by placed values and the candidates in conflict with
+// placed values are left out of the matrix.
+
+type dlxNode struct {
+	left, right, up, down int
+	col                   int // column header of the node
+	row                   int // index of the candidate in dlx.rows
+}
+
+type dlxRow struct {
+	cell  Cell
+	value int
+}
+
+type dlx struct {
+	nodes    []dlxNode // root is 0, column headers are 1..columns
+	size     []int     // number of nodes in the column, by header
+	rows     []dlxRow
+	solution []int // rows of the current partial solution
+}
+
+// newSudokuDLX builds the exact cover matrix of the empty cells of the solver
+func newSudokuDLX(s *Solver) *dlx {
+	length := s.Length
+	l := solverLayout(s)
+
+	// constraint kinds: cell, value in row, value in col, value in block
+	header := make([]int, 4*length*length)
+	d := &dlx{nodes: make([]dlxNode, 1, 1+4*length*length)}
+	d.size = make([]int, 1, cap(d.nodes))
+	addColumn := func(constraint int) {
+		i := len(d.nodes)
+		d.nodes = append(d.nodes, dlxNode{left: i - 1, right: 0, up: i, down: i, col: i, row: -1})
+		d.nodes[i-1].right = i
+		d.nodes[0].left = i
+		d.size = append(d.size, 0)
+		header[constraint] = i
+	}
+
+	constraints := func(cell Cell, value int) [4]int {
+		v := value - 1
+		return [4]int{
+			cell.Row*length + cell.Col,
+			length*length + cell.Row*length + v,
+			2*length*length + cell.Col*length + v,
+			3*length*length + l.blockIndex(cell.Row, cell.Col)*length + v,
+		}
+	}
+
+	candidates := make([][]CandidateSet, length)
+	satisfied := make([]bool, len(header))
+	for r := 0; r < length; r++ {
+		candidates[r] = make([]CandidateSet, length)
+		for c := 0; c < length; c++ {
+			cell := Cell{Row: r, Col: c}
+			if value := s.Problem.Sudoku[r][c]; value != 0 {
+				for _, constraint := range constraints(cell, value) {
+					satisfied[constraint] = true
+				}
+				continue
+			}
+			candidates[r][c] = rowColCandidates(s, r, c)
+		}
+	}
+	for constraint, ok := range satisfied {
+		if !ok {
+			addColumn(constraint)
+		}
+	}
+
+	for r := 0; r < length; r++ {
+		for c := 0; c < length; c++ {
+			cell := Cell{Row: r, Col: c}
+			for _, value := range candidates[r][c].Values() {
+				row := len(d.rows)
+				d.rows = append(d.rows, dlxRow{cell: cell, value: value})
+				first := len(d.nodes)
+				for i, constraint := range constraints(cell, value) {
+					col := header[constraint]
+					n := len(d.nodes)
+					d.nodes = append(d.nodes, dlxNode{left: n - 1, right: first, up: d.nodes[col].up, down: col, col: col, row: row})
+					if i == 0 {
+						d.nodes[n].left = n
+					} else {
+						d.nodes[n-1].right = n
+						d.nodes[first].left = n
+					}
+					d.nodes[d.nodes[col].up].down = n
+					d.nodes[col].up = n
+					d.size[col]++
+				}
+			}
+		}
+	}
+	return d
+}
+
+func (d *dlx) cover(col int) {
+	d.nodes[d.nodes[col].right].left = d.nodes[col].left
+	d.nodes[d.nodes[col].left].right = d.nodes[col].right
+	for i := d.nodes[col].down; i != col; i = d.nodes[i].down {
+		for j := d.nodes[i].right; j != i; j = d.nodes[j].right {
+			d.nodes[d.nodes[j].down].up = d.nodes[j].up
+			d.nodes[d.nodes[j].up].down = d.nodes[j].down
+			d.size[d.nodes[j].col]--
+		}
+	}
+}
+
+func (d *dlx) uncover(col int) {
+	for i := d.nodes[col].up; i != col; i = d.nodes[i].up {
+		for j := d.nodes[i].left; j != i; j = d.nodes[j].left {
+			d.size[d.nodes[j].col]++
+			d.nodes[d.nodes[j].down].up = j
+			d.nodes[d.nodes[j].up].down = j
+		}
+	}
+	d.nodes[d.nodes[col].right].left = col
+	d.nodes[d.nodes[col].left].right = col
+}
+
+// search calls visit with every exact cover, it stops when visit returns false.
+// It returns false when the search was stopped.
+func (d *dlx) search(ctl *control, visit func(rows []int) bool) bool {
+	// the root is depth 1 as in depthFirstSearch
+	if !ctl.node(len(d.solution) + 1) {
+		return false
+	}
+	if d.nodes[0].right == 0 {
+		return visit(d.solution)
+	}
+
+	// minimum remaining values: the column with the fewest candidates
+	col := d.nodes[0].right
+	for i := d.nodes[col].right; i != 0; i = d.nodes[i].right {
+		if d.size[i] < d.size[col] {
+			col = i
+		}
+	}
+	if d.size[col] == 0 {
+		return true
+	}
+
+	d.cover(col)
+	for i := d.nodes[col].down; i != col; i = d.nodes[i].down {
+		d.solution = append(d.solution, d.nodes[i].row)
+		for j := d.nodes[i].right; j != i; j = d.nodes[j].right {
+			d.cover(d.nodes[j].col)
+		}
+		next := d.search(ctl, visit)
+		for j := d.nodes[i].left; j != i; j = d.nodes[j].left {
+			d.uncover(d.nodes[j].col)
+		}
+		d.solution = d.solution[:len(d.solution)-1]
+		if !next {
+			d.uncover(col)
+			return false
+		}
+	}
+	d.uncover(col)
+	return true
+}
+
+// EnumerateDLX calls visit with every solution of the sudoku until visit
+// returns false, the solution is a copy of the problem with all cells set.
+// It returns the number of visited solutions, with nil visit all solutions
+// are counted. The solver is not changed.
+func EnumerateDLX(s *Solver, visit func(solution *SudokuMatrix) bool) (int, error) {
+	return enumerateDLX(s, nil, visit)
+}
+
+func enumerateDLX(s *Solver, ctl *control, visit func(solution *SudokuMatrix) bool) (int, error) {
+	if !s.validated {
+		return 0, ErrNotValidated
+	}
+	d := newSudokuDLX(s)
+	count := 0
+	d.search(ctl, func(rows []int) bool {
+		count++
+		if visit == nil {
+			return true
+		}
+		solution := copyMatrix(&s.Problem)
+		for _, row := range rows {
+			solution.Sudoku[d.rows[row].cell.Row][d.rows[row].cell.Col] = d.rows[row].value
+		}
+		return visit(&solution)
+	})
+	return count, nil
+}
+
+// SolveDLX solves the sudoku with Dancing Links, the first empty cell is
+// returned in *UnsolvableError when there is no solution
+func SolveDLX(s *Solver) (bool, error) {
+	return solveDLX(s, nil)
+}
+
+func solveDLX(s *Solver, ctl *control) (bool, error) {
+	if err := UpdateAllCandidates(s); err != nil {
+		return false, err
+	}
+	var solution *SudokuMatrix
+	var err error
+	applyStrategy(s, "DLX", func(s *Solver) (bool, bool) {
+		if _, err = enumerateDLX(s, ctl, func(m *SudokuMatrix) bool {
+			solution = m
+			return false
+		}); (err != nil) || (solution == nil) {
+			return false, false
+		}
+		for r, row := range solution.Sudoku {
+			for c, value := range row {
+				if s.Problem.Sudoku[r][c] == 0 {
+					s.place(r, c, value)
+				}
+			}
+		}
+		return true, true
+	})
+	if err != nil {
+		return false, err
+	}
+	if err := ctl.error(s); err != nil {
+		return false, err
+	}
+	if solution == nil {
+		return false, unsolvableError(s, 0, 0)
+	}
+	return true, nil
+}
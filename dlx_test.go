@@ -0,0 +1,108 @@
+package solver
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestDLX1(t *testing.T) {
+	tests := []string{
+		"8..........36......7..9.2...5...7.......457.....1...3...1....68..85...1..9....4..", // hard for Depth First Search
+		"1..4....6..3.3..6.5..2....2..56..3..",
+		"1...........4...........7...........A...........2...........5...........8...........B...........3...........6...........9...........C...........",
+	}
+	for i, test := range tests {
+		m, _ := ParseLine(test)
+		s, err := CheckSudoku(m)
+		if err != nil {
+			t.Fatalf("test %v: error: %v\n", i, err)
+		}
+
+		start := time.Now()
+		solved, err := SolveWithOptions(s, SolveOptions{Backend: BackendDLX})
+		fmt.Printf("test %v: total %s\n", i, time.Since(start))
+		if err != nil || !solved {
+			t.Fatalf("test %v: sudoku not solved: %v", i, err)
+		}
+		if err = Validate(&s.Problem); err != nil {
+			t.Errorf("test %v: sudoku not valid: %v", i, err)
+		}
+		for r, row := range s.Problem.Sudoku {
+			for c, value := range row {
+				if (value == 0) || (isGiven(s, r, c) && (value != m.Sudoku[r][c])) {
+					t.Fatalf("test %v: wrong value %v in [%v, %v]", i, value, r, c)
+				}
+			}
+		}
+	}
+}
+
+func TestDLX2(t *testing.T) {
+	// 25x25 from the pattern solution with most cells removed
+	m := SudokuMatrix{Sudoku: make([][]int, 25)}
+	for r := range m.Sudoku {
+		m.Sudoku[r] = make([]int, 25)
+		for c := range m.Sudoku[r] {
+			if (r*7+c*3)%5 == 0 {
+				m.Sudoku[r][c] = (5*(r%5)+r/5+c)%25 + 1
+			}
+		}
+	}
+	s, _ := CheckSudoku(&m)
+	solved, err := SolveWithOptions(s, SolveOptions{Backend: BackendDLX})
+	if err != nil || !solved {
+		t.Fatalf("sudoku not solved: %v", err)
+	}
+	if err = Validate(&s.Problem); err != nil {
+		t.Errorf("sudoku not valid: %v", err)
+	}
+}
+
+func TestDLX3(t *testing.T) {
+	m := SudokuMatrix{Sudoku: [][]int{{0, 0, 0, 0}, {0, 0, 0, 0}, {0, 0, 0, 0}, {0, 0, 0, 0}}}
+	s, _ := CheckSudoku(&m)
+	count, err := EnumerateDLX(s, nil)
+	if err != nil || count != 288 {
+		t.Errorf("expected 288 solutions, got %v %v", count, err)
+	}
+
+	solutions := make([]*SudokuMatrix, 0)
+	count, _ = EnumerateDLX(s, func(solution *SudokuMatrix) bool {
+		solutions = append(solutions, solution)
+		return len(solutions) < 3
+	})
+	if count != 3 || len(solutions) != 3 || FormatLine(solutions[0]) == FormatLine(solutions[1]) {
+		t.Errorf("expected 3 different solutions, got %v", count)
+	}
+	for _, solution := range solutions {
+		if err = Validate(solution); err != nil {
+			t.Errorf("solution not valid: %v", err)
+		}
+	}
+	if FormatLine(&s.Problem) != "................" {
+		t.Errorf("solver changed by EnumerateDLX")
+	}
+}
+
+func TestDLX4(t *testing.T) {
+	m, _ := ParseLine(".1...3.6.....9.2..548.6.......9...2...7...5..1...5........8.45...9.4.8...2.6...93")
+	s, err := CheckSudoku(m)
+	if err != nil {
+		t.Fatalf("error: %v\n", err)
+	}
+	solved, err := SolveWithOptions(s, SolveOptions{Backend: BackendDLX})
+	var unsolvable *UnsolvableError
+	if solved || !errors.As(err, &unsolvable) {
+		t.Errorf("expected %v, got %v", ErrUnsolvable, err)
+	}
+
+	if _, err = SolveWithOptions(s, SolveOptions{Backend: Backend(7)}); err == nil {
+		t.Errorf("expected unknown backend error")
+	}
+	s.validated = false
+	if _, err = EnumerateDLX(s, nil); !errors.Is(err, ErrNotValidated) {
+		t.Errorf("expected %v, got %v", ErrNotValidated, err)
+	}
+}
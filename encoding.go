@@ -0,0 +1,179 @@
+package solver
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// isJSONNull returns whether the JSON value is null
+func isJSONNull(data []byte) bool {
+	return bytes.Equal(bytes.TrimSpace(data), []byte("null"))
+}
+
+// sudokuMatrixState is the JSON and YAML encoding of SudokuMatrix, an object
+// with the grid of values. As text SudokuMatrix is encoded in the single line
+// format (see FormatLine). The YAML methods are the marshaler interfaces of
+// gopkg.in/yaml.v2, which are also supported by gopkg.in/yaml.v3.
+type sudokuMatrixState struct {
+	Sudoku [][]int `json:"sudoku" yaml:"sudoku,flow"`
+}
+
+func (m SudokuMatrix) MarshalText() ([]byte, error) {
+	return []byte(FormatLine(&m)), nil
+}
+
+func (m *SudokuMatrix) UnmarshalText(text []byte) error {
+	parsed, err := ParseLine(string(text))
+	if err != nil {
+		return err
+	}
+	*m = *parsed
+	return nil
+}
+
+func (m SudokuMatrix) MarshalJSON() ([]byte, error) {
+	return json.Marshal(sudokuMatrixState{Sudoku: m.Sudoku})
+}
+
+// UnmarshalJSON accepts both the object and the single line string encoding,
+// null leaves the matrix unchanged
+func (m *SudokuMatrix) UnmarshalJSON(data []byte) error {
+	if isJSONNull(data) {
+		return nil
+	}
+	var line string
+	if err := json.Unmarshal(data, &line); err == nil {
+		return m.UnmarshalText([]byte(line))
+	}
+	state := sudokuMatrixState{}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return err
+	}
+	m.Sudoku = state.Sudoku
+	return nil
+}
+
+func (m SudokuMatrix) MarshalYAML() (interface{}, error) {
+	return sudokuMatrixState{Sudoku: m.Sudoku}, nil
+}
+
+func (m *SudokuMatrix) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var line string
+	if err := unmarshal(&line); err == nil {
+		return m.UnmarshalText([]byte(line))
+	}
+	state := sudokuMatrixState{}
+	if err := unmarshal(&state); err != nil {
+		return err
+	}
+	m.Sudoku = state.Sudoku
+	return nil
+}
+
+// solverState is the encoding of the Solver, it keeps givens and candidates
+// so a partially solved sudoku is restored exactly
+type solverState struct {
+	Length     int       `json:"length" yaml:"length"`
+	Dim        int       `json:"dim" yaml:"dim"`
+	BoxHeight  int       `json:"box_height,omitempty" yaml:"box_height,omitempty"`
+	BoxWidth   int       `json:"box_width,omitempty" yaml:"box_width,omitempty"`
+	Sudoku     [][]int   `json:"sudoku" yaml:"sudoku,flow"`
+	Givens     [][]bool  `json:"givens" yaml:"givens,flow"`
+	Candidates [][][]int `json:"candidates,omitempty" yaml:"candidates,omitempty,flow"`
+}
+
+func newSolverState(s *Solver) solverState {
+	return solverState{
+		Length:     s.Length,
+		Dim:        s.Dim,
+		BoxHeight:  s.BoxHeight,
+		BoxWidth:   s.BoxWidth,
+		Sudoku:     s.Problem.Sudoku,
+		Givens:     s.Givens,
+		Candidates: s.CandidateSlices(),
+	}
+}
+
+// restore validates the decoded state and sets it to the solver, the block
+// size is BoxSize when it is missing
+func (state *solverState) restore(s *Solver) error {
+	boxHeight, boxWidth := state.BoxHeight, state.BoxWidth
+	if (boxHeight == 0) && (boxWidth == 0) {
+		boxHeight, boxWidth, _ = BoxSize(len(state.Sudoku))
+	}
+	v, err := CheckSudokuBoxes(&SudokuMatrix{Sudoku: state.Sudoku}, boxHeight, boxWidth)
+	if err != nil {
+		return err
+	}
+	if (v.Length != state.Length) || (v.Dim != state.Dim) {
+		return fmt.Errorf("ERROR: Length %v and dim %v don't match the sudoku matrix", state.Length, state.Dim)
+	}
+	if state.Givens != nil {
+		if len(state.Givens) != v.Length {
+			return fmt.Errorf("ERROR: Givens don't match the sudoku matrix")
+		}
+		for r, row := range state.Givens {
+			if len(row) != v.Length {
+				return fmt.Errorf("ERROR: Givens don't match the sudoku matrix")
+			}
+			for c, given := range row {
+				if given && (state.Sudoku[r][c] == 0) {
+					return fmt.Errorf("ERROR: Given without value in pos [%v, %v]", r, c)
+				}
+			}
+		}
+		v.Givens = state.Givens
+	}
+	if state.Candidates != nil {
+		if len(state.Candidates) != v.Length {
+			return fmt.Errorf("ERROR: Candidates don't match the sudoku matrix")
+		}
+		for r, row := range state.Candidates {
+			if len(row) != v.Length {
+				return fmt.Errorf("ERROR: Candidates don't match the sudoku matrix")
+			}
+			for c, candidates := range row {
+				if (len(candidates) > 0) && (state.Sudoku[r][c] != 0) {
+					return fmt.Errorf("ERROR: Candidates with value in pos [%v, %v]", r, c)
+				}
+				for i, candidate := range candidates {
+					if (candidate < 1) || (candidate > v.Length) || ((i > 0) && (candidates[i-1] >= candidate)) {
+						return fmt.Errorf("ERROR: Invalid candidates in pos [%v, %v]", r, c)
+					}
+				}
+			}
+		}
+		v.SetCandidateSlices(state.Candidates)
+	}
+	*s = *v
+	return nil
+}
+
+func (s Solver) MarshalJSON() ([]byte, error) {
+	return json.Marshal(newSolverState(&s))
+}
+
+// UnmarshalJSON restores the solver state, null leaves the solver unchanged
+func (s *Solver) UnmarshalJSON(data []byte) error {
+	if isJSONNull(data) {
+		return nil
+	}
+	state := solverState{}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return err
+	}
+	return state.restore(s)
+}
+
+func (s Solver) MarshalYAML() (interface{}, error) {
+	return newSolverState(&s), nil
+}
+
+func (s *Solver) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	state := solverState{}
+	if err := unmarshal(&state); err != nil {
+		return err
+	}
+	return state.restore(s)
+}
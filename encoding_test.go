@@ -0,0 +1,157 @@
+package solver
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestEncoding1(t *testing.T) {
+	m, _ := ParseLine("1.....2..3.....4")
+
+	data, err := json.Marshal(m)
+	if err != nil {
+		t.Fatalf("error: %v\n", err)
+	}
+	expected := `{"sudoku":[[1,0,0,0],[0,0,2,0],[0,3,0,0],[0,0,0,4]]}`
+	if string(data) != expected {
+		t.Errorf("expected %v, got %v", expected, string(data))
+	}
+
+	var m2 SudokuMatrix
+	if err = json.Unmarshal([]byte(`"1.....2..3.....4"`), &m2); err != nil {
+		t.Fatalf("error: %v\n", err)
+	}
+	if !reflect.DeepEqual(*m, m2) {
+		t.Errorf("expected %v, got %v", *m, m2)
+	}
+
+	text, _ := m.MarshalText()
+	if string(text) != "1.....2..3.....4" {
+		t.Errorf("wrong text %v", string(text))
+	}
+}
+
+func TestEncoding2(t *testing.T) {
+	m, _ := ParseLine("8....7.9..29..4..63..2..........65...174...3.2.........941...7...8..........7...3")
+	s, err := CheckSudoku(m)
+	if err != nil {
+		t.Fatalf("error: %v\n", err)
+	}
+	UpdateAllCandidates(s)
+	SolveNakedSingle(s)
+
+	data, err := json.Marshal(s)
+	if err != nil {
+		t.Fatalf("error: %v\n", err)
+	}
+	fmt.Println(string(data))
+
+	var s2 Solver
+	if err = json.Unmarshal(data, &s2); err != nil {
+		t.Fatalf("error: %v\n", err)
+	}
+	if !reflect.DeepEqual(*s, s2) {
+		t.Errorf("solver state not restored")
+	}
+	if !s2.Givens[0][0] || s2.Givens[0][1] {
+		t.Errorf("wrong givens")
+	}
+
+	invalid := `{"length":4,"dim":2,"sudoku":[[1,0,0,0],[0,0,2,0],[0,3,0,0],[0,0,0,4]],"givens":[[false,false,false,false],[false,false,false,false],[false,false,false,false],[false,false,false,false]],"candidates":[[[],[2,3],[],[]],[[],[],[],[]],[[],[],[],[]],[[],[],[5],[]]]}`
+	err = json.Unmarshal([]byte(invalid), &s2)
+	fmt.Println(err)
+	if err == nil {
+		t.Errorf("expected error on invalid candidates")
+	}
+}
+
+func TestEncoding3(t *testing.T) {
+	m, _ := ParseLine("1.....2..3.....4")
+
+	data, err := yaml.Marshal(m)
+	if err != nil {
+		t.Fatalf("error: %v\n", err)
+	}
+	expected := "sudoku: [[1, 0, 0, 0], [0, 0, 2, 0], [0, 3, 0, 0], [0, 0, 0, 4]]\n"
+	if string(data) != expected {
+		t.Errorf("expected %q, got %q", expected, string(data))
+	}
+
+	var m2 SudokuMatrix
+	if err = yaml.Unmarshal(data, &m2); err != nil {
+		t.Fatalf("error: %v\n", err)
+	}
+	if !reflect.DeepEqual(*m, m2) {
+		t.Errorf("expected %v, got %v", *m, m2)
+	}
+	var m3 SudokuMatrix
+	if err = yaml.Unmarshal([]byte(`"1.....2..3.....4"`), &m3); err != nil {
+		t.Fatalf("error: %v\n", err)
+	}
+	if !reflect.DeepEqual(*m, m3) {
+		t.Errorf("expected %v, got %v", *m, m3)
+	}
+}
+
+func TestEncoding4(t *testing.T) {
+	m, _ := ParseLine("8....7.9..29..4..63..2..........65...174...3.2.........941...7...8..........7...3")
+	s, err := CheckSudoku(m)
+	if err != nil {
+		t.Fatalf("error: %v\n", err)
+	}
+	UpdateAllCandidates(s)
+	SolveNakedSingle(s)
+
+	data, err := yaml.Marshal(s)
+	if err != nil {
+		t.Fatalf("error: %v\n", err)
+	}
+	fmt.Println(string(data))
+
+	var s2 Solver
+	if err = yaml.Unmarshal(data, &s2); err != nil {
+		t.Fatalf("error: %v\n", err)
+	}
+	if !reflect.DeepEqual(*s, s2) {
+		t.Errorf("solver state not restored")
+	}
+
+	invalid := "length: 4\ndim: 2\nsudoku: [[1, 0, 0, 0], [0, 0, 2, 0], [0, 3, 0, 0], [0, 0, 0, 4]]\ngivens: [[false, false, false, false], [false, false, false, false], [false, false, false, false], [false, false, false, false]]\ncandidates: [[[], [2, 3], [], []], [[], [], [], []], [[], [], [], []], [[], [], [5], []]]\n"
+	err = yaml.Unmarshal([]byte(invalid), &s2)
+	fmt.Println(err)
+	if err == nil {
+		t.Errorf("expected error on invalid candidates")
+	}
+}
+
+func TestEncoding5(t *testing.T) {
+	// null leaves the values unchanged
+	m, _ := ParseLine("1.....2..3.....4")
+	s, _ := CheckSudoku(m)
+	m2 := *m
+	if err := json.Unmarshal([]byte(" null "), &m2); err != nil {
+		t.Fatalf("error: %v\n", err)
+	}
+	if !reflect.DeepEqual(*m, m2) {
+		t.Errorf("expected %v, got %v", *m, m2)
+	}
+	s2 := *s
+	if err := json.Unmarshal([]byte("null"), &s2); err != nil {
+		t.Fatalf("error: %v\n", err)
+	}
+	if !reflect.DeepEqual(*s, s2) {
+		t.Errorf("solver changed by null")
+	}
+
+	// candidates of the given 1 in [0, 0]
+	invalid := `{"length":4,"dim":2,"sudoku":[[1,0,0,0],[0,0,2,0],[0,3,0,0],[0,0,0,4]],"givens":[[true,false,false,false],[false,false,true,false],[false,true,false,false],[false,false,false,true]],"candidates":[[[1],[2,4],[3,4],[2,3]],[[2,3,4],[1,4],[],[1,3]],[[2,4],[],[1,4],[1,2]],[[2,3],[1,2],[1,3],[]]]}`
+	err := json.Unmarshal([]byte(invalid), &s2)
+	fmt.Println(err)
+	if err == nil {
+		t.Errorf("expected error on candidates of a cell with value")
+	}
+}
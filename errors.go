@@ -0,0 +1,30 @@
+package solver
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrNotValidated is returned by the solver when it runs on a Solver that was
+// not returned by CheckSudoku without error
+var ErrNotValidated = errors.New("ERROR: Sudoku is not validated")
+
+var ErrUnsolvable = errors.New("ERROR: Sudoku is unsolvable")
+
+// ErrBudgetExhausted is the cause of *InterruptedError when a limit of the
+// Budget is reached
+var ErrBudgetExhausted = errors.New("ERROR: Solver budget exhausted")
+
+// UnsolvableError is the cell where the contradiction was found, it matches
+// ErrUnsolvable with errors.Is
+type UnsolvableError struct {
+	Cell
+}
+
+func (e *UnsolvableError) Error() string {
+	return fmt.Sprintf("%v in pos [%v, %v]", ErrUnsolvable, e.Row, e.Col)
+}
+
+func (e *UnsolvableError) Unwrap() error {
+	return ErrUnsolvable
+}
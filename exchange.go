@@ -0,0 +1,227 @@
+package solver
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// position exchange formats are defined for sudoku up to 9x9 (single digits)
+const maxExchangeLength = 9
+
+func checkExchangeLength(s *Solver) error {
+	if s.Length > maxExchangeLength {
+		return fmt.Errorf("ERROR: Invalid sudoku length %v for the exchange format, max %v", s.Length, maxExchangeLength)
+	}
+	return nil
+}
+
+// basicCandidates returns candidates of all empty cells without the values
+// placed in the row, col and block
+func basicCandidates(s *Solver) [][]CandidateSet {
+	candidates := newCandidateSets(s.Length)
+	for r := range candidates {
+		for c := range candidates[r] {
+			if s.Problem.Sudoku[r][c] == 0 {
+				candidates[r][c] = rowColCandidates(s, r, c)
+			}
+		}
+	}
+	return candidates
+}
+
+// ParseHodoku parses the Hodoku step format ":0000:x:grid:deleted::". In the
+// grid givens are digits, placed digits are prefixed with '+' and empty cells
+// are '.' or '0'. Deleted candidates are space separated digit, row and col
+// triplets (e.g. "512" deletes 5 in row 1, col 2). A plain grid is also accepted.
+func ParseHodoku(text string) (*Solver, error) {
+	text = strings.TrimSpace(text)
+	grid, deleted := text, ""
+	if strings.HasPrefix(text, ":") {
+		fields := strings.Split(text, ":")
+		if len(fields) < 4 {
+			return nil, &ParseError{Pos: len(text), Msg: "Missing grid"}
+		}
+		grid = fields[3]
+		if len(fields) > 4 {
+			deleted = fields[4]
+		}
+	}
+
+	values := make([]int, 0, 81)
+	placed := make([]bool, 0, 81)
+	plus := false
+	for pos, ch := range grid {
+		switch {
+		case ch == '+':
+			plus = true
+			continue
+		case ch == '.' || ch == '0':
+			values = append(values, 0)
+		case ch >= '1' && ch <= '9':
+			values = append(values, int(ch-'0'))
+		default:
+			return nil, &ParseError{Pos: pos, Char: ch, Msg: "Invalid character"}
+		}
+		placed = append(placed, plus)
+		plus = false
+	}
+
+	length := int(math.Sqrt(float64(len(values))))
+	if (length*length != len(values)) || (length > maxExchangeLength) {
+		return nil, &ParseError{Pos: len(grid), Msg: fmt.Sprintf("Invalid sudoku length %v", len(values))}
+	}
+	m := SudokuMatrix{Sudoku: make([][]int, length)}
+	for r := range m.Sudoku {
+		m.Sudoku[r] = values[r*length : (r+1)*length]
+	}
+	s, err := CheckSudoku(&m)
+	if err != nil {
+		return nil, err
+	}
+	for i, p := range placed {
+		if p {
+			s.Givens[i/length][i%length] = false
+		}
+	}
+
+	s.Candidates = basicCandidates(s)
+	for _, triplet := range strings.Fields(deleted) {
+		if len(triplet) != 3 {
+			return nil, &ParseError{Msg: fmt.Sprintf("Invalid deleted candidate %q", triplet)}
+		}
+		d, r, c := int(triplet[0]-'0'), int(triplet[1]-'1'), int(triplet[2]-'1')
+		if (d < 1) || (d > length) || (r < 0) || (r >= length) || (c < 0) || (c >= length) {
+			return nil, &ParseError{Msg: fmt.Sprintf("Invalid deleted candidate %q", triplet)}
+		}
+		s.Candidates[r][c] = s.Candidates[r][c].Remove(d)
+	}
+	return s, nil
+}
+
+// FormatHodoku returns the Hodoku step format of the solver state, candidates
+// missing from Solver.Candidates are written as deleted. Only sudoku up to 9x9
+// are supported.
+func FormatHodoku(s *Solver) (string, error) {
+	if err := checkExchangeLength(s); err != nil {
+		return "", err
+	}
+	var grid strings.Builder
+	for r, row := range s.Problem.Sudoku {
+		for c, value := range row {
+			if value == 0 {
+				grid.WriteString(NullValue)
+				continue
+			}
+			if !isGiven(s, r, c) {
+				grid.WriteString("+")
+			}
+			grid.WriteString(strconv.Itoa(value))
+		}
+	}
+
+	deleted := make([]string, 0)
+	if s.Candidates != nil {
+		for r, row := range basicCandidates(s) {
+			for c, candidates := range row {
+				for _, candidate := range candidates.Minus(s.Candidates[r][c]).Values() {
+					deleted = append(deleted, fmt.Sprintf("%v%v%v", candidate, r+1, c+1))
+				}
+			}
+		}
+	}
+	return fmt.Sprintf(":0000:x:%v:%v::", grid.String(), strings.Join(deleted, " ")), nil
+}
+
+// S9B packed format: "S9B" followed by two base 32 digits for every cell.
+// The number of an empty cell is the mask of its candidates (bit 0 for 1),
+// a placed digit d is 512+d and a given digit d is 512+16+d.
+const s9bPrefix = "S9B"
+const s9bSolved = 512
+const s9bGiven = 16
+
+func ParseS9B(text string) (*Solver, error) {
+	text = strings.TrimSpace(text)
+	if !strings.HasPrefix(strings.ToUpper(text), s9bPrefix) {
+		return nil, &ParseError{Msg: "Missing S9B prefix"}
+	}
+	packed := text[len(s9bPrefix):]
+	cells := len(packed) / 2
+	length := int(math.Sqrt(float64(cells)))
+	if (len(packed)%2 != 0) || (length*length != cells) || (length > maxExchangeLength) {
+		return nil, &ParseError{Pos: len(text), Msg: fmt.Sprintf("Invalid sudoku length %v", cells)}
+	}
+
+	numbers := make([]int, cells)
+	m := SudokuMatrix{Sudoku: make([][]int, length)}
+	for r := range m.Sudoku {
+		m.Sudoku[r] = make([]int, length)
+	}
+	for i := range numbers {
+		pos := len(s9bPrefix) + 2*i
+		n, err := strconv.ParseUint(text[pos:pos+2], 32, 16)
+		if err != nil {
+			return nil, &ParseError{Pos: pos, Row: i / length, Col: i % length, Msg: fmt.Sprintf("Invalid cell %q", text[pos:pos+2])}
+		}
+		numbers[i] = int(n)
+		if numbers[i] >= s9bSolved {
+			value := (numbers[i] - s9bSolved) % s9bGiven
+			if (value < 1) || (value > length) {
+				return nil, &ParseError{Pos: pos, Row: i / length, Col: i % length, Msg: fmt.Sprintf("Invalid cell %q", text[pos:pos+2])}
+			}
+			m.Sudoku[i/length][i%length] = value
+		}
+	}
+
+	s, err := CheckSudoku(&m)
+	if err != nil {
+		return nil, err
+	}
+	s.Candidates = newCandidateSets(length)
+	for r := range s.Candidates {
+		for c := range s.Candidates[r] {
+			n := numbers[r*length+c]
+			if n >= s9bSolved {
+				s.Givens[r][c] = (n - s9bSolved) >= s9bGiven
+				continue
+			}
+			s.Candidates[r][c] = CandidateSet(n).Intersect(AllCandidates(length))
+		}
+	}
+	return s, nil
+}
+
+// FormatS9B returns the S9B packed format of the solver state, only sudoku up
+// to 9x9 are supported
+func FormatS9B(s *Solver) (string, error) {
+	if err := checkExchangeLength(s); err != nil {
+		return "", err
+	}
+	candidates := s.Candidates
+	if candidates == nil {
+		candidates = basicCandidates(s)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(s9bPrefix)
+	for r, row := range s.Problem.Sudoku {
+		for c, value := range row {
+			n := 0
+			if value != 0 {
+				n = s9bSolved + value
+				if isGiven(s, r, c) {
+					n += s9bGiven
+				}
+			} else {
+				n = int(candidates[r][c])
+			}
+			digits := strconv.FormatInt(int64(n), 32)
+			if len(digits) < 2 {
+				sb.WriteString("0")
+			}
+			sb.WriteString(digits)
+		}
+	}
+	return sb.String(), nil
+}
@@ -0,0 +1,102 @@
+package solver
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func exchangeSolver() *Solver {
+	m, _ := ParseLine("8....7.9..29..4..63..2..........65...174...3.2.........941...7...8..........7...3")
+	s, _ := CheckSudoku(m)
+	UpdateAllCandidates(s)
+	SolveNakedSingle(s)
+	SolveNakedPair(s)
+	return s
+}
+
+func TestExchange1(t *testing.T) {
+	s := exchangeSolver()
+	text, err := FormatHodoku(s)
+	if err != nil {
+		t.Fatalf("error: %v\n", err)
+	}
+	fmt.Println(text)
+
+	s2, err := ParseHodoku(text)
+	if err != nil {
+		t.Fatalf("error: %v\n", err)
+	}
+	if !reflect.DeepEqual(s.Problem, s2.Problem) || !reflect.DeepEqual(s.Givens, s2.Givens) {
+		t.Errorf("values not restored")
+	}
+	for r := range s.Candidates {
+		for c := range s.Candidates[r] {
+			if s.Candidates[r][c] != 0 && s.Candidates[r][c] != s2.Candidates[r][c] {
+				t.Errorf("candidates not restored in [%v, %v]: %v, %v", r, c, s.Candidates[r][c], s2.Candidates[r][c])
+			}
+		}
+	}
+	if text2, _ := FormatHodoku(s2); text2 != text {
+		t.Errorf("expected %v, got %v", text, text2)
+	}
+
+	s3, err := ParseHodoku(":0000:x:1..+3..2..3.....4:212::")
+	if err != nil {
+		t.Fatalf("error: %v\n", err)
+	}
+	if s3.Problem.Sudoku[0][3] != 3 || s3.Givens[0][3] || !s3.Givens[0][0] {
+		t.Errorf("wrong placed values")
+	}
+	if s3.Candidates[0][1] != NewCandidateSet(4) || s3.Candidates[1][0] == 0 {
+		t.Errorf("wrong candidates: %v", s3.Candidates)
+	}
+
+	if _, err = ParseHodoku(":0000:x:1..+3..2..3.....4:2x1::"); err == nil {
+		t.Errorf("expected error on invalid deleted candidate")
+	}
+}
+
+func TestExchange2(t *testing.T) {
+	s := exchangeSolver()
+	text, err := FormatS9B(s)
+	if err != nil {
+		t.Fatalf("error: %v\n", err)
+	}
+	fmt.Println(text)
+	if len(text) != 3+2*81 {
+		t.Errorf("wrong length %v", len(text))
+	}
+
+	s2, err := ParseS9B(text)
+	if err != nil {
+		t.Fatalf("error: %v\n", err)
+	}
+	if !reflect.DeepEqual(s.Problem, s2.Problem) || !reflect.DeepEqual(s.Givens, s2.Givens) {
+		t.Errorf("values not restored")
+	}
+	for r := range s.Candidates {
+		for c := range s.Candidates[r] {
+			if s.Candidates[r][c].Count() != s2.Candidates[r][c].Count() {
+				t.Errorf("candidates not restored in [%v, %v]: %v, %v", r, c, s.Candidates[r][c], s2.Candidates[r][c])
+			}
+		}
+	}
+
+	if _, err = ParseS9B("S9Bzz" + text[5:]); err == nil {
+		t.Errorf("expected error on invalid cell")
+	}
+}
+
+func TestExchange3(t *testing.T) {
+	// 12x12 values and candidates do not fit in the exchange formats
+	m, _ := ParseLine("1...........4...........7...........A...........2...........5...........8...........B...........3...........6...........9...........C...........")
+	s, _ := CheckSudoku(m)
+	UpdateAllCandidates(s)
+	if _, err := FormatHodoku(s); err == nil {
+		t.Errorf("expected Hodoku length error")
+	}
+	if _, err := FormatS9B(s); err == nil {
+		t.Errorf("expected S9B length error")
+	}
+}
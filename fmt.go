@@ -1,30 +1,178 @@
 package solver
 
 import (
+	"bufio"
 	"fmt"
-	"strconv"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
 )
 
+// default render options
 const NullValue = "."
 const Space = "  "
+const DiffMarker = "*"
 
-func Print(s *Solver) {
-	l := len(strconv.Itoa(s.Length))
+type RenderOptions struct {
+	NullValue string     // shown in empty cells
+	Space     string     // shown between blocks
+	Symbols   *SymbolSet // default symbol set of the sudoku size when nil
+}
+
+func (o RenderOptions) withDefaults(s *Solver) RenderOptions {
+	if o.NullValue == "" {
+		o.NullValue = NullValue
+	}
+	if o.Space == "" {
+		o.Space = Space
+	}
+	o.Symbols = symbolsOrDefault(o.Symbols, s.Length)
+	return o
+}
+
+type Renderer interface {
+	Render(w io.Writer, s *Solver) error
+}
+
+// CompactRenderer renders placed values with blocks separated by spaces
+type CompactRenderer struct {
+	Options RenderOptions
+}
+
+// BoxRenderer renders placed values with Unicode borders around blocks
+type BoxRenderer struct {
+	Options RenderOptions
+}
+
+// CandidatesRenderer renders placed values and candidates in the layout read by
+// ReadPencilMarks (see WritePencilMarks), so only the symbol set is an option
+type CandidatesRenderer struct {
+	Symbols *SymbolSet // default symbol set of the sudoku size when nil
+}
 
+// DiffRenderer renders placed values and marks the ones that differ from the
+// Original puzzle of the same size, the givens of the solver are used when
+// Original is nil
+type DiffRenderer struct {
+	Options  RenderOptions
+	Original *SudokuMatrix
+	Marker   string
+}
+
+func valueWidth(o *RenderOptions) int {
+	return max(1, utf8.RuneCountInString(o.NullValue))
+}
+
+func valueText(value int, o *RenderOptions) string {
+	if value == 0 {
+		return o.NullValue
+	}
+	return string(o.Symbols.Symbol(value))
+}
+
+// renderCompact writes the layout of Print, every value is padded and then
+// decorated (e.g. followed by a space or a marker)
+func renderCompact(w io.Writer, s *Solver, o RenderOptions, decorate func(row int, col int, padded string) string) error {
+	l := valueWidth(&o)
+
+	bw := bufio.NewWriter(w)
 	for rInd, row := range s.Problem.Sudoku {
-		if (rInd != 0) && (rInd%s.Dim) == 0 {
-			fmt.Println()
+		if (rInd != 0) && (rInd%s.BoxHeight) == 0 {
+			bw.WriteString("\n")
 		}
 		for cInd, value := range row {
-			if (cInd != 0) && (cInd%s.Dim) == 0 {
-				fmt.Print(Space)
+			if (cInd != 0) && (cInd%s.BoxWidth) == 0 {
+				bw.WriteString(o.Space)
 			}
-			if value == 0 {
-				fmt.Printf("%*v ", l, NullValue)
-			} else {
-				fmt.Printf("%*v ", l, value)
+			bw.WriteString(decorate(rInd, cInd, fmt.Sprintf("%*v", l, valueText(value, &o))))
+		}
+		bw.WriteString("\n")
+	}
+	return bw.Flush()
+}
+
+func (r CompactRenderer) Render(w io.Writer, s *Solver) error {
+	return renderCompact(w, s, r.Options.withDefaults(s), func(row int, col int, padded string) string { return padded + " " })
+}
+
+func sameSize(m *SudokuMatrix, length int) bool {
+	if len(m.Sudoku) != length {
+		return false
+	}
+	for _, row := range m.Sudoku {
+		if len(row) != length {
+			return false
+		}
+	}
+	return true
+}
+
+func (r DiffRenderer) Render(w io.Writer, s *Solver) error {
+	marker := r.Marker
+	if marker == "" {
+		marker = DiffMarker
+	}
+	blank := strings.Repeat(" ", utf8.RuneCountInString(marker))
+	if (r.Original != nil) && !sameSize(r.Original, s.Length) {
+		return fmt.Errorf("ERROR: Original doesn't match the sudoku size %v", s.Length)
+	}
+
+	return renderCompact(w, s, r.Options.withDefaults(s), func(row int, col int, padded string) string {
+		value := s.Problem.Sudoku[row][col]
+		changed := false
+		if r.Original != nil {
+			changed = value != r.Original.Sudoku[row][col]
+		} else if s.Givens != nil {
+			changed = (value != 0) && !s.Givens[row][col]
+		}
+		if changed {
+			return padded + marker
+		}
+		return padded + blank
+	})
+}
+
+func (r BoxRenderer) Render(w io.Writer, s *Solver) error {
+	o := r.Options.withDefaults(s)
+	l := valueWidth(&o)
+
+	border := func(left string, middle string, right string) string {
+		block := strings.Repeat("─", 1+s.BoxWidth*(l+1))
+		return left + strings.TrimSuffix(strings.Repeat(block+middle, s.BoxHeight), middle) + right + "\n"
+	}
+
+	bw := bufio.NewWriter(w)
+	bw.WriteString(border("┌", "┬", "┐"))
+	for rInd, row := range s.Problem.Sudoku {
+		if (rInd != 0) && (rInd%s.BoxHeight) == 0 {
+			bw.WriteString(border("├", "┼", "┤"))
+		}
+		for cInd, value := range row {
+			if (cInd % s.BoxWidth) == 0 {
+				bw.WriteString("│ ")
 			}
+			fmt.Fprintf(bw, "%*v ", l, valueText(value, &o))
 		}
-		fmt.Println()
+		bw.WriteString("│\n")
 	}
+	bw.WriteString(border("└", "┴", "┘"))
+	return bw.Flush()
+}
+
+func (r CandidatesRenderer) Render(w io.Writer, s *Solver) error {
+	return writePencilMarks(w, s, symbolsOrDefault(r.Symbols, s.Length))
+}
+
+// Fprint renders the sudoku to w with the renderer r
+func Fprint(w io.Writer, s *Solver, r Renderer) error {
+	return r.Render(w, s)
+}
+
+func Print(s *Solver) {
+	Fprint(os.Stdout, s, CompactRenderer{})
+}
+
+func PrintCandidates(s *Solver) {
+	Fprint(os.Stdout, s, CandidatesRenderer{})
 }
@@ -0,0 +1,127 @@
+package solver
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestRenderer1(t *testing.T) {
+	m, _ := ParseLine("1.....2..3.....4")
+	s, _ := CheckSudoku(m)
+
+	var sb strings.Builder
+	Fprint(&sb, s, CompactRenderer{})
+	expected := "1 .   . . \n. .   2 . \n\n. 3   . . \n. .   . 4 \n"
+	if sb.String() != expected {
+		t.Errorf("expected %q, got %q", expected, sb.String())
+	}
+
+	sb.Reset()
+	Fprint(&sb, s, CompactRenderer{Options: RenderOptions{NullValue: "_", Space: "|"}})
+	expected = "1 _ |_ _ \n_ _ |2 _ \n\n_ 3 |_ _ \n_ _ |_ 4 \n"
+	if sb.String() != expected {
+		t.Errorf("expected %q, got %q", expected, sb.String())
+	}
+
+	sb.Reset()
+	Fprint(&sb, s, BoxRenderer{})
+	fmt.Print(sb.String())
+	expected = "┌─────┬─────┐\n│ 1 . │ . . │\n│ . . │ 2 . │\n├─────┼─────┤\n│ . 3 │ . . │\n│ . . │ . 4 │\n└─────┴─────┘\n"
+	if sb.String() != expected {
+		t.Errorf("expected %q, got %q", expected, sb.String())
+	}
+}
+
+func TestRenderer2(t *testing.T) {
+	m, _ := ParseLine("1.....2..3.....4")
+	s, _ := CheckSudoku(m)
+	solved, err := Solve(s)
+	if err != nil || !solved {
+		t.Fatalf("Sudoku not solved")
+	}
+
+	var sb strings.Builder
+	Fprint(&sb, s, DiffRenderer{})
+	fmt.Print(sb.String())
+	lines := strings.Split(sb.String(), "\n")
+	if !strings.HasPrefix(lines[0], "1 ") || strings.Count(sb.String(), DiffMarker) != 12 {
+		t.Errorf("wrong diff: %q", sb.String())
+	}
+
+	original, _ := ParseLine("1..." + "..2." + ".3.." + "....")
+	sb.Reset()
+	Fprint(&sb, s, DiffRenderer{Original: original, Marker: "!"})
+	if strings.Count(sb.String(), "!") != 13 {
+		t.Errorf("wrong diff: %q", sb.String())
+	}
+}
+
+func TestRenderer3(t *testing.T) {
+	m, _ := ParseLine("1..4....6..3.3..6.5..2....2..56..3..")
+	s, _ := CheckSudoku(m)
+
+	var sb strings.Builder
+	Fprint(&sb, s, CompactRenderer{})
+	expected := "1 . .   4 . . \n. . 6   . . 3 \n\n. 3 .   . 6 . \n5 . .   2 . . \n\n. . 2   . . 5 \n6 . .   3 . . \n"
+	if sb.String() != expected {
+		t.Errorf("expected %q, got %q", expected, sb.String())
+	}
+
+	sb.Reset()
+	Fprint(&sb, s, BoxRenderer{})
+	fmt.Print(sb.String())
+	expected = "┌───────┬───────┐\n│ 1 . . │ 4 . . │\n│ . . 6 │ . . 3 │\n├───────┼───────┤\n│ . 3 . │ . 6 . │\n│ 5 . . │ 2 . . │\n├───────┼───────┤\n│ . . 2 │ . . 5 │\n│ 6 . . │ 3 . . │\n└───────┴───────┘\n"
+	if sb.String() != expected {
+		t.Errorf("expected %q, got %q", expected, sb.String())
+	}
+
+	sb.Reset()
+	WriteSS(&sb, &GridFile{Puzzle: *m})
+	expected = "1..|4..\n..6|..3\n---+---\n.3.|.6.\n5..|2..\n---+---\n..2|..5\n6..|3..\n"
+	if sb.String() != expected {
+		t.Errorf("expected %q, got %q", expected, sb.String())
+	}
+	gf, err := ReadSS(strings.NewReader(sb.String()))
+	if err != nil || FormatLine(&gf.Puzzle) != FormatLine(m) {
+		t.Errorf("wrong puzzle: %v %v", err, gf)
+	}
+}
+
+func TestRenderer4(t *testing.T) {
+	m, _ := ParseLine("1.....2..3.....4")
+	s, _ := CheckSudoku(m)
+	Solve(s)
+
+	// blank cells are as wide as the marker in runes
+	var sb strings.Builder
+	original, _ := ParseLine("1..." + "..2." + ".3.." + "...4")
+	if err := Fprint(&sb, s, DiffRenderer{Original: original, Marker: "←"}); err != nil {
+		t.Fatalf("error: %v\n", err)
+	}
+	fmt.Print(sb.String())
+	lines := strings.Split(sb.String(), "\n")
+	if utf8.RuneCountInString(lines[0]) != utf8.RuneCountInString(lines[1]) {
+		t.Errorf("wrong padding: %q", sb.String())
+	}
+
+	small, _ := ParseLine("1.....2..3.....4")
+	small.Sudoku = small.Sudoku[:3]
+	err := Fprint(&sb, s, DiffRenderer{Original: small})
+	fmt.Println(err)
+	if err == nil {
+		t.Errorf("expected error on original of different size")
+	}
+
+	// the candidates layout is read back with the symbol set
+	m, _ = ParseLine("1.....2..3.....4")
+	s, _ = CheckSudoku(m)
+	UpdateAllCandidates(s)
+	sb.Reset()
+	Fprint(&sb, s, CandidatesRenderer{Symbols: SymbolsLetters})
+	s2, err := ReadPencilMarks(strings.NewReader(sb.String()))
+	if err != nil || (s2.Problem.Sudoku[0][0] != 1) || (s2.Candidates[0][1] != s.Candidates[0][1]) {
+		t.Errorf("wrong candidates: %v %q", err, sb.String())
+	}
+}
@@ -0,0 +1,284 @@
+package solver
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+)
+
+type HouseKind int
+
+const (
+	HouseRow HouseKind = iota + 1
+	HouseCol
+	HouseBlock
+)
+
+func (k HouseKind) String() string {
+	switch k {
+	case HouseRow:
+		return "row"
+	case HouseCol:
+		return "col"
+	case HouseBlock:
+		return "block"
+	}
+	return fmt.Sprintf("HouseKind(%d)", int(k))
+}
+
+// House is a row, col or block of the sudoku. Blocks are numbered row by row,
+// the cells are in row by row order. Cells are shared lookup tables and must
+// not be modified.
+type House struct {
+	Kind  HouseKind
+	Index int
+	Cells []Cell
+}
+
+// layout holds the lookup tables of a sudoku size, it is shared by all grids
+// and solvers of the same size
+type layout struct {
+	length    int
+	boxHeight int
+	boxWidth  int
+	houses    []House  // rows, cols and then blocks
+	peers     [][]Cell // cells in the same row, col or block, by cell index
+}
+
+var layouts sync.Map // [3]int{length, boxHeight, boxWidth} -> *layout
+
+func layoutOf(length int, boxHeight int, boxWidth int) *layout {
+	key := [3]int{length, boxHeight, boxWidth}
+	if l, ok := layouts.Load(key); ok {
+		return l.(*layout)
+	}
+	l, _ := layouts.LoadOrStore(key, newLayout(length, boxHeight, boxWidth))
+	return l.(*layout)
+}
+
+// solverLayout returns the lookup tables for the size of the solver
+func solverLayout(s *Solver) *layout {
+	return layoutOf(s.Length, s.BoxHeight, s.BoxWidth)
+}
+
+func newLayout(length int, boxHeight int, boxWidth int) *layout {
+	l := &layout{length: length, boxHeight: boxHeight, boxWidth: boxWidth}
+	l.houses = make([]House, 3*length)
+	for i := 0; i < length; i++ {
+		l.houses[i] = House{Kind: HouseRow, Index: i, Cells: make([]Cell, 0, length)}
+		l.houses[length+i] = House{Kind: HouseCol, Index: i, Cells: make([]Cell, 0, length)}
+		l.houses[2*length+i] = House{Kind: HouseBlock, Index: i, Cells: make([]Cell, 0, length)}
+	}
+	for r := 0; r < length; r++ {
+		for c := 0; c < length; c++ {
+			cell := Cell{Row: r, Col: c}
+			l.houses[r].Cells = append(l.houses[r].Cells, cell)
+			l.houses[length+c].Cells = append(l.houses[length+c].Cells, cell)
+			l.houses[2*length+l.blockIndex(r, c)].Cells = append(l.houses[2*length+l.blockIndex(r, c)].Cells, cell)
+		}
+	}
+
+	l.peers = make([][]Cell, length*length)
+	for r := 0; r < length; r++ {
+		for c := 0; c < length; c++ {
+			peers := make([]Cell, 0, 3*length)
+			for _, house := range l.housesOf(r, c) {
+				for _, peer := range house.Cells {
+					if (peer.Row == r) && (peer.Col == c) {
+						continue
+					}
+					if (house.Kind == HouseBlock) && ((peer.Row == r) || (peer.Col == c)) {
+						continue // already a peer in the row or col
+					}
+					peers = append(peers, peer)
+				}
+			}
+			l.peers[r*length+c] = peers
+		}
+	}
+	return l
+}
+
+func (l *layout) blockIndex(row int, col int) int {
+	return (row/l.boxHeight)*l.boxHeight + col/l.boxWidth
+}
+
+func (l *layout) row(row int) *House {
+	return &l.houses[row]
+}
+
+func (l *layout) col(col int) *House {
+	return &l.houses[l.length+col]
+}
+
+func (l *layout) block(row int, col int) *House {
+	return &l.houses[2*l.length+l.blockIndex(row, col)]
+}
+
+// housesOf returns the row, col and block of the cell
+func (l *layout) housesOf(row int, col int) [3]*House {
+	return [3]*House{l.row(row), l.col(col), l.block(row, col)}
+}
+
+// Grid is an immutable sudoku matrix. Grids of the same size share the lookup
+// tables of houses and peers, Set copies the values (copy on write).
+type Grid struct {
+	layout *layout
+	values []int // row by row, never modified after the grid is created
+}
+
+// NewGrid returns the grid of the sudoku matrix with the block size BoxSize,
+// the matrix is copied
+func NewGrid(m *SudokuMatrix) (*Grid, error) {
+	boxHeight, boxWidth, _ := BoxSize(len(m.Sudoku))
+	return NewGridBoxes(m, boxHeight, boxWidth)
+}
+
+// NewGridBoxes is NewGrid with the block size boxHeight x boxWidth. Only the
+// size and values are checked, duplicates are reported by Validate.
+func NewGridBoxes(m *SudokuMatrix, boxHeight int, boxWidth int) (*Grid, error) {
+	length := len(m.Sudoku)
+	if !validBoxSize(length, boxHeight, boxWidth) {
+		return nil, &Violation{Kind: ViolationDimension, Unit: length}
+	}
+	values := make([]int, 0, length*length)
+	for r, row := range m.Sudoku {
+		if len(row) != length {
+			return nil, &Violation{Kind: ViolationNotSquare, Unit: r}
+		}
+		for c, value := range row {
+			if (value < 0) || (value > length) {
+				return nil, &Violation{Kind: ViolationRange, Unit: r, Value: value, Cells: [][2]Cell{{{Row: r, Col: c}, {Row: r, Col: c}}}}
+			}
+		}
+		values = append(values, row...)
+	}
+	return &Grid{layout: layoutOf(length, boxHeight, boxWidth), values: values}, nil
+}
+
+// Grid returns the grid of the current values of the solver
+func (s *Solver) Grid() *Grid {
+	values := make([]int, 0, s.Length*s.Length)
+	for _, row := range s.Problem.Sudoku {
+		values = append(values, row...)
+	}
+	return &Grid{layout: solverLayout(s), values: values}
+}
+
+func (g *Grid) Length() int {
+	return g.layout.length
+}
+
+func (g *Grid) BoxHeight() int {
+	return g.layout.boxHeight
+}
+
+func (g *Grid) BoxWidth() int {
+	return g.layout.boxWidth
+}
+
+// Value returns the value of the cell, 0 for empty cells
+func (g *Grid) Value(c Cell) int {
+	return g.values[c.Row*g.layout.length+c.Col]
+}
+
+// Set returns a copy of the grid with the value of the cell, the grid is unchanged
+func (g *Grid) Set(c Cell, value int) *Grid {
+	values := make([]int, len(g.values))
+	copy(values, g.values)
+	values[c.Row*g.layout.length+c.Col] = value
+	return &Grid{layout: g.layout, values: values}
+}
+
+// Matrix returns a copy of the values as sudoku matrix
+func (g *Grid) Matrix() SudokuMatrix {
+	length := g.layout.length
+	m := SudokuMatrix{Sudoku: make([][]int, length)}
+	for r := range m.Sudoku {
+		m.Sudoku[r] = make([]int, length)
+		copy(m.Sudoku[r], g.values[r*length:(r+1)*length])
+	}
+	return m
+}
+
+// Cells returns all cells row by row
+func (g *Grid) Cells() []Cell {
+	cells := make([]Cell, 0, len(g.values))
+	for _, house := range g.layout.houses[:g.layout.length] {
+		cells = append(cells, house.Cells...)
+	}
+	return cells
+}
+
+// EmptyCells returns the cells without value row by row
+func (g *Grid) EmptyCells() []Cell {
+	cells := make([]Cell, 0)
+	for i, value := range g.values {
+		if value == 0 {
+			cells = append(cells, Cell{Row: i / g.layout.length, Col: i % g.layout.length})
+		}
+	}
+	return cells
+}
+
+// cloneHouse returns a copy of the house of the shared layout tables
+func cloneHouse(h *House) House {
+	return House{Kind: h.Kind, Index: h.Index, Cells: slices.Clone(h.Cells)}
+}
+
+func (g *Grid) Row(row int) House {
+	return cloneHouse(g.layout.row(row))
+}
+
+func (g *Grid) Col(col int) House {
+	return cloneHouse(g.layout.col(col))
+}
+
+// Block returns the block with the index, blocks are numbered row by row
+func (g *Grid) Block(index int) House {
+	return cloneHouse(&g.layout.houses[2*g.layout.length+index])
+}
+
+// Houses returns all rows, cols and then blocks
+func (g *Grid) Houses() []House {
+	houses := make([]House, len(g.layout.houses))
+	for i := range g.layout.houses {
+		houses[i] = cloneHouse(&g.layout.houses[i])
+	}
+	return houses
+}
+
+// HousesOf returns the row, col and block of the cell
+func (g *Grid) HousesOf(c Cell) [3]House {
+	houses := g.layout.housesOf(c.Row, c.Col)
+	return [3]House{cloneHouse(houses[0]), cloneHouse(houses[1]), cloneHouse(houses[2])}
+}
+
+// BlockOf returns the index of the block of the cell
+func (g *Grid) BlockOf(c Cell) int {
+	return g.layout.blockIndex(c.Row, c.Col)
+}
+
+// Peers returns the cells in the same row, col or block without the cell
+func (g *Grid) Peers(c Cell) []Cell {
+	return slices.Clone(g.layout.peers[c.Row*g.layout.length+c.Col])
+}
+
+// Candidates returns the values not placed in the peers of the cell in
+// ascending order, nil for cells with value
+func (g *Grid) Candidates(c Cell) []int {
+	if g.Value(c) != 0 {
+		return nil
+	}
+	placed := make([]bool, g.layout.length+1)
+	for _, peer := range g.layout.peers[c.Row*g.layout.length+c.Col] {
+		placed[g.Value(peer)] = true
+	}
+	candidates := make([]int, 0, g.layout.length)
+	for value := 1; value <= g.layout.length; value++ {
+		if !placed[value] {
+			candidates = append(candidates, value)
+		}
+	}
+	return candidates
+}
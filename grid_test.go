@@ -0,0 +1,116 @@
+package solver
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestGrid1(t *testing.T) {
+	m, _ := ParseLine("1..4....6..3.3..6.5..2....2..56..3..")
+	g, err := NewGrid(m)
+	if err != nil {
+		t.Fatalf("error: %v\n", err)
+	}
+	if g.Length() != 6 || g.BoxHeight() != 2 || g.BoxWidth() != 3 {
+		t.Fatalf("wrong size %v %vx%v", g.Length(), g.BoxHeight(), g.BoxWidth())
+	}
+
+	houses := g.Houses()
+	if len(houses) != 18 {
+		t.Fatalf("expected 18 houses, got %v", len(houses))
+	}
+	for _, house := range houses {
+		if len(house.Cells) != 6 {
+			t.Errorf("%v %v: expected 6 cells, got %v", house.Kind, house.Index, len(house.Cells))
+		}
+	}
+
+	block := g.Block(3)
+	expected := []Cell{{2, 3}, {2, 4}, {2, 5}, {3, 3}, {3, 4}, {3, 5}}
+	for i, cell := range block.Cells {
+		if cell != expected[i] {
+			t.Errorf("block 3: expected %v, got %v", expected, block.Cells)
+			break
+		}
+	}
+	if g.BlockOf(Cell{Row: 3, Col: 4}) != 3 {
+		t.Errorf("wrong block of [3, 4]: %v", g.BlockOf(Cell{Row: 3, Col: 4}))
+	}
+	houses3 := g.HousesOf(Cell{Row: 3, Col: 4})
+	if houses3[0].Kind != HouseRow || houses3[0].Index != 3 || houses3[1].Index != 4 || houses3[2].Index != 3 {
+		t.Errorf("wrong houses of [3, 4]: %v", houses3)
+	}
+
+	// 5 in the row, 5 in the col and 2 more in the block
+	peers := g.Peers(Cell{Row: 0, Col: 0})
+	if len(peers) != 12 {
+		t.Errorf("expected 12 peers, got %v", len(peers))
+	}
+	for _, peer := range peers {
+		if peer == (Cell{Row: 0, Col: 0}) {
+			t.Errorf("cell is its own peer")
+		}
+	}
+
+	candidates := g.Candidates(Cell{Row: 0, Col: 1})
+	if len(candidates) != 2 || candidates[0] != 2 || candidates[1] != 5 {
+		t.Errorf("expected [2 5], got %v", candidates)
+	}
+	if len(g.EmptyCells()) != 24 || len(g.Cells()) != 36 {
+		t.Errorf("wrong cells %v %v", len(g.EmptyCells()), len(g.Cells()))
+	}
+}
+
+func TestGrid2(t *testing.T) {
+	m, _ := ParseLine("1.....2..3.....4")
+	g, _ := NewGrid(m)
+
+	cell := Cell{Row: 0, Col: 1}
+	g2 := g.Set(cell, 3)
+	if g.Value(cell) != 0 || g2.Value(cell) != 3 {
+		t.Errorf("grid changed by Set: %v %v", g.Value(cell), g2.Value(cell))
+	}
+	if m.Sudoku[0][1] != 0 {
+		t.Errorf("matrix changed by Set")
+	}
+	matrix := g2.Matrix()
+	matrix.Sudoku[0][0] = 4
+	if g2.Value(Cell{Row: 0, Col: 0}) != 1 {
+		t.Errorf("grid changed by the matrix")
+	}
+
+	s, _ := CheckSudoku(m)
+	if FormatLine(&SudokuMatrix{Sudoku: s.Grid().Matrix().Sudoku}) != FormatLine(m) {
+		t.Errorf("wrong solver grid")
+	}
+
+	var v *Violation
+	if _, err := NewGrid(&SudokuMatrix{Sudoku: [][]int{{1, 2, 3}, {0, 0, 0}, {0, 0, 0}}}); !errors.As(err, &v) || v.Kind != ViolationDimension {
+		t.Errorf("expected dimension violation, got %v", err)
+	}
+	if _, err := NewGridBoxes(m, 1, 4); !errors.As(err, &v) || v.Kind != ViolationDimension {
+		t.Errorf("expected dimension violation, got %v", err)
+	}
+}
+
+func TestGrid3(t *testing.T) {
+	// changed houses and peers do not change the shared layout tables
+	m, _ := ParseLine("1.....2..3.....4")
+	g, _ := NewGrid(m)
+	s, _ := CheckSudoku(m)
+
+	cell := Cell{Row: 1, Col: 1}
+	peer := g.Peers(cell)[0]
+	g.Peers(cell)[0] = Cell{Row: 3, Col: 3}
+	g.Row(1).Cells[0] = Cell{Row: 3, Col: 3}
+	g.Houses()[0].Cells[0] = Cell{Row: 3, Col: 3}
+	g.HousesOf(cell)[2].Cells[0] = Cell{Row: 3, Col: 3}
+
+	l := solverLayout(s)
+	if (l.peers[5][0] != peer) || (g.Peers(cell)[0] != peer) {
+		t.Errorf("peers changed: %v", l.peers[5])
+	}
+	if (l.row(1).Cells[0] != Cell{Row: 1, Col: 0}) || (l.row(0).Cells[0] != Cell{Row: 0, Col: 0}) || (l.block(0, 0).Cells[0] != Cell{Row: 0, Col: 0}) {
+		t.Errorf("houses changed: %v %v %v", l.row(0), l.row(1), l.block(0, 0))
+	}
+}
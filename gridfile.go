@@ -0,0 +1,166 @@
+package solver
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// GridFile is a puzzle read from or written to a grid file (.sdk, .ss) with
+// its metadata header lines (without the leading '#', e.g. "A author").
+// Symbols is the detected symbol set when read, the default symbol set is
+// written when nil.
+type GridFile struct {
+	Puzzle  SudokuMatrix
+	Meta    []string
+	Symbols *SymbolSet
+}
+
+// blanks besides '.', '0' and '_'
+const sdkBlanks = ""
+const ssBlanks = "Xx*"
+
+// box separators and borders ignored inside grid rows
+const gridBorders = "|:! \t"
+
+func isSeparatorLine(text string) bool {
+	return strings.Trim(text, "-+*|=.:' \t") == "" && strings.ContainsAny(text, "-=")
+}
+
+func readGrid(r io.Reader, extraBlanks string) (*GridFile, error) {
+	gf := GridFile{}
+	rows := make([][]rune, 0, 9)
+	rowLines := make([]int, 0, 9)
+
+	scanner := bufio.NewScanner(r)
+	line := 0
+	inPuzzle := true
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		switch {
+		case text == "":
+			continue
+		case strings.HasPrefix(text, "#"):
+			gf.Meta = append(gf.Meta, strings.TrimSpace(text[1:]))
+			continue
+		case strings.HasPrefix(text, "["): // sections of the full .sdk format, only [Puzzle] holds the givens
+			inPuzzle = strings.EqualFold(text, "[Puzzle]")
+			continue
+		case !inPuzzle || isSeparatorLine(text):
+			continue
+		}
+
+		row := make([]rune, 0, len(text))
+		for _, ch := range text {
+			if !strings.ContainsRune(gridBorders, ch) {
+				row = append(row, ch)
+			}
+		}
+		rows = append(rows, row)
+		rowLines = append(rowLines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	length := len(rows)
+	if _, _, ok := BoxSize(length); !ok {
+		return nil, &ParseError{Line: line, Msg: fmt.Sprintf("Invalid number of rows %v", length)}
+	}
+
+	text := make([]rune, 0, length*length)
+	for _, row := range rows {
+		text = append(text, row...)
+	}
+	gf.Symbols = detectSymbolSet(string(text), length, extraBlanks)
+
+	gf.Puzzle.Sudoku = make([][]int, length)
+	for r, row := range rows {
+		if len(row) != length {
+			return nil, &ParseError{Line: rowLines[r], Pos: len(row), Msg: fmt.Sprintf("Invalid row length %v", len(row))}
+		}
+		gf.Puzzle.Sudoku[r] = make([]int, length)
+		for c, ch := range row {
+			if gf.Symbols.IsBlank(ch) {
+				continue
+			}
+			value, ok := gf.Symbols.Value(ch)
+			if !ok && strings.ContainsRune(extraBlanks, ch) {
+				continue
+			}
+			if !ok || value > length {
+				return nil, &ParseError{Line: rowLines[r], Pos: c, Row: r, Col: c, Char: ch, Msg: "Invalid character"}
+			}
+			gf.Puzzle.Sudoku[r][c] = value
+		}
+	}
+	return &gf, nil
+}
+
+// ReadSDK reads a SadMan Software .sdk file, both the plain grid and the
+// sectioned format with '#' metadata and a [Puzzle] section are supported
+func ReadSDK(r io.Reader) (*GridFile, error) {
+	return readGrid(r, sdkBlanks)
+}
+
+// ReadSS reads a Simple Sudoku .ss file with '|' and "---+---+---" box separators
+func ReadSS(r io.Reader) (*GridFile, error) {
+	return readGrid(r, ssBlanks)
+}
+
+func writeMeta(bw *bufio.Writer, meta []string) {
+	for _, m := range meta {
+		fmt.Fprintf(bw, "#%v\n", m)
+	}
+}
+
+func WriteSDK(w io.Writer, gf *GridFile) error {
+	symbols := symbolsOrDefault(gf.Symbols, len(gf.Puzzle.Sudoku))
+	bw := bufio.NewWriter(w)
+	writeMeta(bw, gf.Meta)
+	for _, row := range gf.Puzzle.Sudoku {
+		for _, value := range row {
+			if value == 0 {
+				bw.WriteString(NullValue)
+			} else {
+				bw.WriteRune(symbols.Symbol(value))
+			}
+		}
+		bw.WriteString("\n")
+	}
+	return bw.Flush()
+}
+
+// WriteSS writes the puzzle with box separators in the same block layout as Print
+func WriteSS(w io.Writer, gf *GridFile) error {
+	length := len(gf.Puzzle.Sudoku)
+	boxHeight, boxWidth, ok := BoxSize(length)
+	if !ok {
+		return fmt.Errorf("ERROR: Invalid sudoku length %v", length)
+	}
+	symbols := symbolsOrDefault(gf.Symbols, length)
+
+	separator := strings.TrimSuffix(strings.Repeat(strings.Repeat("-", boxWidth)+"+", boxHeight), "+")
+
+	bw := bufio.NewWriter(w)
+	writeMeta(bw, gf.Meta)
+	for rInd, row := range gf.Puzzle.Sudoku {
+		if (rInd != 0) && (rInd%boxHeight) == 0 {
+			bw.WriteString(separator + "\n")
+		}
+		for cInd, value := range row {
+			if (cInd != 0) && (cInd%boxWidth) == 0 {
+				bw.WriteString("|")
+			}
+			if value == 0 {
+				bw.WriteString(NullValue)
+			} else {
+				bw.WriteRune(symbols.Symbol(value))
+			}
+		}
+		bw.WriteString("\n")
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,86 @@
+package solver
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestGridFile1(t *testing.T) {
+	input := `#Aauthor
+#Dsample puzzle
+[Puzzle]
+8....7.9.
+.29..4..6
+3..2.....
+.....65..
+.174...3.
+2........
+.941...7.
+..8......
+....7...3
+[State]
+812657394
+`
+	gf, err := ReadSDK(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("error: %v\n", err)
+	}
+	if len(gf.Meta) != 2 || gf.Meta[0] != "Aauthor" {
+		t.Errorf("wrong meta: %v", gf.Meta)
+	}
+	if FormatLine(&gf.Puzzle) != "8....7.9..29..4..63..2..........65...174...3.2.........941...7...8..........7...3" {
+		t.Errorf("wrong puzzle: %v", FormatLine(&gf.Puzzle))
+	}
+
+	var sb strings.Builder
+	WriteSDK(&sb, gf)
+	expected := "#Aauthor\n#Dsample puzzle\n8....7.9.\n.29..4..6\n3..2.....\n.....65..\n.174...3.\n2........\n.941...7.\n..8......\n....7...3\n"
+	if sb.String() != expected {
+		t.Errorf("expected %q, got %q", expected, sb.String())
+	}
+}
+
+func TestGridFile2(t *testing.T) {
+	input := `*-----------*
+|8..|..7|.9.|
+|.29|..4|..6|
+|3..|2..|...|
+|---+---+---|
+|...|..6|5..|
+|.17|4..|.3.|
+|2..|...|...|
+|---+---+---|
+|.94|1..|.7.|
+|..8|...|...|
+|...|.X.|..3|
+*-----------*
+`
+	gf, err := ReadSS(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("error: %v\n", err)
+	}
+	if gf.Puzzle.Sudoku[8][8] != 3 || gf.Puzzle.Sudoku[8][4] != 0 {
+		t.Errorf("wrong puzzle: %v", FormatLine(&gf.Puzzle))
+	}
+
+	var sb strings.Builder
+	WriteSS(&sb, gf)
+	fmt.Print(sb.String())
+	gf2, err := ReadSS(strings.NewReader(sb.String()))
+	if err != nil {
+		t.Fatalf("error: %v\n", err)
+	}
+	if FormatLine(&gf.Puzzle) != FormatLine(&gf2.Puzzle) {
+		t.Errorf("expected %v, got %v", FormatLine(&gf.Puzzle), FormatLine(&gf2.Puzzle))
+	}
+	if !strings.HasPrefix(sb.String(), "8..|..7|.9.\n.29|..4|..6\n3..|2..|...\n---+---+---\n") {
+		t.Errorf("wrong layout: %q", sb.String())
+	}
+
+	_, err = ReadSS(strings.NewReader("12|..\n..|3.\n--+--\n..|..\n4.|.\n"))
+	fmt.Println(err)
+	if err == nil {
+		t.Errorf("expected error on short row")
+	}
+}
@@ -0,0 +1,75 @@
+package solver
+
+import (
+	"slices"
+)
+
+// CellCandidate is a single candidate value of a cell
+type CellCandidate struct {
+	Cell
+	Value int
+}
+
+// Highlights selects cells and candidates emphasized by renderers, usually the
+// cells touched and the candidates eliminated in the last step. Eliminated
+// candidates are no longer in Solver.Candidates and are rendered separately.
+type Highlights struct {
+	Cells      []Cell
+	Candidates []CellCandidate
+	Eliminated []CellCandidate
+}
+
+func (h *Highlights) hasCell(row int, col int) bool {
+	if h == nil {
+		return false
+	}
+	for _, cell := range h.Cells {
+		if (cell.Row == row) && (cell.Col == col) {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *Highlights) hasCandidate(row int, col int, value int) bool {
+	if h == nil {
+		return false
+	}
+	for _, cc := range h.Candidates {
+		if (cc.Row == row) && (cc.Col == col) && (cc.Value == value) {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *Highlights) isEliminated(row int, col int, value int) bool {
+	if h == nil {
+		return false
+	}
+	for _, cc := range h.Eliminated {
+		if (cc.Row == row) && (cc.Col == col) && (cc.Value == value) {
+			return true
+		}
+	}
+	return false
+}
+
+// cellCandidates returns the candidates of the cell merged with the eliminated
+// candidates in ascending order
+func (h *Highlights) cellCandidates(s *Solver, row int, col int) []int {
+	candidates := make([]int, 0, s.Length)
+	if s.Candidates != nil {
+		candidates = append(candidates, s.Candidates[row][col].Values()...)
+	}
+	if h == nil {
+		return candidates
+	}
+	for _, cc := range h.Eliminated {
+		if (cc.Row == row) && (cc.Col == col) && !slices.Contains(candidates, cc.Value) {
+			candidates = append(candidates, cc.Value)
+		}
+	}
+	slices.Sort(candidates)
+	return candidates
+}
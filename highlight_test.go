@@ -0,0 +1,64 @@
+package solver
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func highlightedSolver() (*Solver, *Highlights) {
+	m, _ := ParseLine("1.....2..3.....4")
+	s, _ := CheckSudoku(m)
+	UpdateAllCandidates(s)
+
+	// place 4 in [0, 1] and remove it from the candidates of the row
+	s.Problem.Sudoku[0][1] = 4
+	s.Candidates[0][1] = 0
+	s.Candidates[0][2] = NewCandidateSet(3)
+	highlights := &Highlights{
+		Cells:      []Cell{{Row: 0, Col: 1}},
+		Candidates: []CellCandidate{{Cell: Cell{Row: 0, Col: 2}, Value: 3}},
+		Eliminated: []CellCandidate{{Cell: Cell{Row: 0, Col: 2}, Value: 4}},
+	}
+	return s, highlights
+}
+
+func TestHighlight1(t *testing.T) {
+	s, highlights := highlightedSolver()
+
+	var sb strings.Builder
+	Fprint(&sb, s, ANSIRenderer{Candidates: true, Highlights: highlights})
+	fmt.Print(sb.String())
+	out := sb.String()
+	if !strings.Contains(out, ansiSolved+ansiTouched+"4") {
+		t.Errorf("touched cell not highlighted: %q", out)
+	}
+	if !strings.Contains(out, ansiEliminated+"4") || !strings.Contains(out, ansiHighlight+"3") {
+		t.Errorf("candidates not highlighted: %q", out)
+	}
+
+	sb.Reset()
+	Fprint(&sb, s, ANSIRenderer{})
+	fmt.Print(sb.String())
+	if !strings.HasPrefix(sb.String(), ansi(ansiGiven, "1")+" "+ansi(ansiSolved, "4")+" ") {
+		t.Errorf("values not colored: %q", sb.String())
+	}
+}
+
+func TestHighlight2(t *testing.T) {
+	s, highlights := highlightedSolver()
+
+	var sb strings.Builder
+	Fprint(&sb, s, HTMLRenderer{Candidates: true, Highlights: highlights, NoStyle: true})
+	out := sb.String()
+	fmt.Print(out)
+	if strings.Count(out, "<tr>") != 4 || strings.Count(out, "<td") != 16 {
+		t.Errorf("invalid table: %v", out)
+	}
+	if !strings.Contains(out, `<td class="bt bl given">1</td>`) || !strings.Contains(out, `<td class="bt solved touched">4</td>`) {
+		t.Errorf("wrong cell classes: %v", out)
+	}
+	if !strings.Contains(out, `<span class="highlight">3</span><span class="eliminated">4</span>`) {
+		t.Errorf("candidates not highlighted: %v", out)
+	}
+}
@@ -0,0 +1,110 @@
+package solver
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"io"
+	"strings"
+)
+
+// HTMLStyle is the default style of tables written by HTMLRenderer
+const HTMLStyle = `<style>
+table.sudoku { border-collapse: collapse; font-family: sans-serif; }
+table.sudoku td { width: 2.4em; height: 2.4em; padding: 0; border: 1px solid #999; text-align: center; vertical-align: middle; font-size: 1.4em; }
+table.sudoku td.bt { border-top: 3px solid #000; }
+table.sudoku td.bl { border-left: 3px solid #000; }
+table.sudoku td.bb { border-bottom: 3px solid #000; }
+table.sudoku td.br { border-right: 3px solid #000; }
+table.sudoku td.given { font-weight: bold; }
+table.sudoku td.solved { color: #1a5fb4; }
+table.sudoku td.touched { background: #ffe680; }
+table.sudoku div.candidates { display: grid; font-size: 0.4em; color: #606060; }
+table.sudoku span.highlight { background: #a0e0a0; }
+table.sudoku span.eliminated { color: #d02020; text-decoration: line-through; }
+</style>
+`
+
+// HTMLRenderer renders the sudoku as HTML table with the highlights of
+// ANSIRenderer as CSS classes, HTMLStyle is written before the table unless
+// NoStyle is set
+type HTMLRenderer struct {
+	Options    RenderOptions
+	Candidates bool
+	Highlights *Highlights
+	NoStyle    bool
+}
+
+func (r HTMLRenderer) cellClasses(s *Solver, row int, col int) string {
+	classes := make([]string, 0, 6)
+	if (row % s.BoxHeight) == 0 {
+		classes = append(classes, "bt")
+	}
+	if (col % s.BoxWidth) == 0 {
+		classes = append(classes, "bl")
+	}
+	if row == s.Length-1 {
+		classes = append(classes, "bb")
+	}
+	if col == s.Length-1 {
+		classes = append(classes, "br")
+	}
+	if s.Problem.Sudoku[row][col] != 0 {
+		if isGiven(s, row, col) {
+			classes = append(classes, "given")
+		} else {
+			classes = append(classes, "solved")
+		}
+	}
+	if r.Highlights.hasCell(row, col) {
+		classes = append(classes, "touched")
+	}
+	return strings.Join(classes, " ")
+}
+
+func (r HTMLRenderer) Render(w io.Writer, s *Solver) error {
+	o := r.Options.withDefaults(s)
+
+	bw := bufio.NewWriter(w)
+	if !r.NoStyle {
+		bw.WriteString(HTMLStyle)
+	}
+	bw.WriteString(`<table class="sudoku">` + "\n")
+	for row := 0; row < s.Length; row++ {
+		bw.WriteString("<tr>")
+		for col := 0; col < s.Length; col++ {
+			fmt.Fprintf(bw, `<td class="%v">`, r.cellClasses(s, row, col))
+			if value := s.Problem.Sudoku[row][col]; value != 0 {
+				bw.WriteString(html.EscapeString(string(o.Symbols.Symbol(value))))
+			} else if r.Candidates {
+				r.writeCandidates(bw, s, &o, row, col)
+			}
+			bw.WriteString("</td>")
+		}
+		bw.WriteString("</tr>\n")
+	}
+	bw.WriteString("</table>\n")
+	return bw.Flush()
+}
+
+// writeCandidates writes every candidate at its position in a grid of the block size
+func (r HTMLRenderer) writeCandidates(bw *bufio.Writer, s *Solver, o *RenderOptions, row int, col int) {
+	candidates := r.Highlights.cellCandidates(s, row, col)
+	fmt.Fprintf(bw, `<div class="candidates" style="grid-template-columns: repeat(%v, 1fr)">`, s.BoxWidth)
+	i := 0
+	for value := 1; value <= s.Length; value++ {
+		if (i >= len(candidates)) || (candidates[i] != value) {
+			bw.WriteString("<span></span>")
+			continue
+		}
+		i++
+		class := ""
+		if r.Highlights.isEliminated(row, col, value) {
+			class = ` class="eliminated"`
+		} else if r.Highlights.hasCandidate(row, col, value) {
+			class = ` class="highlight"`
+		}
+		fmt.Fprintf(bw, "<span%v>%v</span>", class, html.EscapeString(string(o.Symbols.Symbol(value))))
+	}
+	bw.WriteString("</div>")
+}
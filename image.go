@@ -0,0 +1,277 @@
+package solver
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+)
+
+const DefaultCellSize = 48
+
+type ImageOptions struct {
+	CellSize       int // size of a cell in pixels, DefaultCellSize when 0
+	HideCandidates bool
+	Highlights     *Highlights
+	Symbols        *SymbolSet // default symbol set of the sudoku size when nil
+}
+
+func (o ImageOptions) withDefaults(s *Solver) ImageOptions {
+	if o.CellSize <= 0 {
+		o.CellSize = DefaultCellSize
+	}
+	o.Symbols = symbolsOrDefault(o.Symbols, s.Length)
+	return o
+}
+
+// SVGRenderer renders the sudoku as SVG image
+type SVGRenderer struct {
+	Options ImageOptions
+}
+
+// PNGRenderer renders the sudoku as PNG image
+type PNGRenderer struct {
+	Options ImageOptions
+}
+
+var (
+	colorBackground         = color.RGBA{0xff, 0xff, 0xff, 0xff}
+	colorGiven              = color.RGBA{0x00, 0x00, 0x00, 0xff}
+	colorSolved             = color.RGBA{0x1a, 0x5f, 0xb4, 0xff}
+	colorCandidate          = color.RGBA{0x60, 0x60, 0x60, 0xff}
+	colorThinLine           = color.RGBA{0x99, 0x99, 0x99, 0xff}
+	colorThickLine          = color.RGBA{0x00, 0x00, 0x00, 0xff}
+	colorHighlightCell      = color.RGBA{0xff, 0xe6, 0x80, 0xff}
+	colorHighlightCandidate = color.RGBA{0xa0, 0xe0, 0xa0, 0xff}
+	colorEliminated         = color.RGBA{0xd0, 0x20, 0x20, 0xff}
+)
+
+func hexColor(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+func isGiven(s *Solver, row int, col int) bool {
+	return (s.Givens != nil) && s.Givens[row][col]
+}
+
+// imageMargin leaves room for the outer thick border
+func imageMargin(o *ImageOptions) int {
+	return max(2, o.CellSize/16)
+}
+
+func (r SVGRenderer) Render(w io.Writer, s *Solver) error {
+	o := r.Options.withDefaults(s)
+	cell := o.CellSize
+	margin := imageMargin(&o)
+	size := s.Length*cell + 2*margin
+	sub := float64(cell) / float64(max(s.BoxHeight, s.BoxWidth)) // pencil mark sub cell
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, `<svg xmlns="http://www.w3.org/2000/svg" width="%v" height="%v" viewBox="0 0 %v %v">`+"\n", size, size, size, size)
+	fmt.Fprintf(bw, `<rect width="%v" height="%v" fill="%v"/>`+"\n", size, size, hexColor(colorBackground))
+
+	fmt.Fprintf(bw, `<g font-family="sans-serif" text-anchor="middle" dominant-baseline="central">`+"\n")
+	for row := 0; row < s.Length; row++ {
+		for col := 0; col < s.Length; col++ {
+			x := margin + col*cell
+			y := margin + row*cell
+			if o.Highlights.hasCell(row, col) {
+				fmt.Fprintf(bw, `<rect x="%v" y="%v" width="%v" height="%v" fill="%v"/>`+"\n", x, y, cell, cell, hexColor(colorHighlightCell))
+			}
+
+			if value := s.Problem.Sudoku[row][col]; value != 0 {
+				style := fmt.Sprintf(`fill="%v"`, hexColor(colorSolved))
+				if isGiven(s, row, col) {
+					style = fmt.Sprintf(`fill="%v" font-weight="bold"`, hexColor(colorGiven))
+				}
+				fmt.Fprintf(bw, `<text x="%v" y="%v" font-size="%v" %v>%v</text>`+"\n",
+					x+cell/2, y+cell/2, cell*3/5, style, html.EscapeString(string(o.Symbols.Symbol(value))))
+				continue
+			}
+			if o.HideCandidates {
+				continue
+			}
+			for _, candidate := range o.Highlights.cellCandidates(s, row, col) {
+				cx := float64(x) + float64((candidate-1)%s.BoxWidth)*sub
+				cy := float64(y) + float64((candidate-1)/s.BoxWidth)*sub
+				if o.Highlights.hasCandidate(row, col, candidate) {
+					fmt.Fprintf(bw, `<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" fill="%v"/>`+"\n",
+						cx, cy, sub, sub, hexColor(colorHighlightCandidate))
+				}
+				style := fmt.Sprintf(`fill="%v"`, hexColor(colorCandidate))
+				if o.Highlights.isEliminated(row, col, candidate) {
+					style = fmt.Sprintf(`fill="%v" text-decoration="line-through"`, hexColor(colorEliminated))
+				}
+				fmt.Fprintf(bw, `<text x="%.1f" y="%.1f" font-size="%.1f" %v>%v</text>`+"\n",
+					cx+sub/2, cy+sub/2, sub*0.7, style, html.EscapeString(string(o.Symbols.Symbol(candidate))))
+			}
+		}
+	}
+	fmt.Fprintf(bw, "</g>\n")
+
+	for i := 0; i <= s.Length; i++ {
+		pos := margin + i*cell
+		stroke, width := colorThinLine, 1
+		if (i % s.BoxWidth) == 0 {
+			stroke, width = colorThickLine, 3
+		}
+		fmt.Fprintf(bw, `<line x1="%v" y1="%v" x2="%v" y2="%v" stroke="%v" stroke-width="%v" stroke-linecap="square"/>`+"\n",
+			pos, margin, pos, size-margin, hexColor(stroke), width)
+		stroke, width = colorThinLine, 1
+		if (i % s.BoxHeight) == 0 {
+			stroke, width = colorThickLine, 3
+		}
+		fmt.Fprintf(bw, `<line x1="%v" y1="%v" x2="%v" y2="%v" stroke="%v" stroke-width="%v" stroke-linecap="square"/>`+"\n",
+			margin, pos, size-margin, pos, hexColor(stroke), width)
+	}
+	fmt.Fprintf(bw, "</svg>\n")
+	return bw.Flush()
+}
+
+// RenderImage draws the sudoku to a new image with the 5x7 glyphs of digits and
+// capital letters, symbols without glyph are not drawn
+func RenderImage(s *Solver, options ImageOptions) *image.RGBA {
+	o := options.withDefaults(s)
+	cell := o.CellSize
+	margin := imageMargin(&o)
+	size := s.Length*cell + 2*margin
+	sub := cell / max(s.BoxHeight, s.BoxWidth)
+
+	img := image.NewRGBA(image.Rect(0, 0, size, size))
+	fillRect(img, img.Bounds(), colorBackground)
+
+	for row := 0; row < s.Length; row++ {
+		for col := 0; col < s.Length; col++ {
+			x := margin + col*cell
+			y := margin + row*cell
+			if o.Highlights.hasCell(row, col) {
+				fillRect(img, image.Rect(x, y, x+cell, y+cell), colorHighlightCell)
+			}
+
+			if value := s.Problem.Sudoku[row][col]; value != 0 {
+				c := colorSolved
+				if isGiven(s, row, col) {
+					c = colorGiven
+				}
+				drawGlyph(img, o.Symbols.Symbol(value), x+cell/2, y+cell/2, cell*3/5, c)
+				continue
+			}
+			if o.HideCandidates {
+				continue
+			}
+			for _, candidate := range o.Highlights.cellCandidates(s, row, col) {
+				cx := x + ((candidate-1)%s.BoxWidth)*sub
+				cy := y + ((candidate-1)/s.BoxWidth)*sub
+				if o.Highlights.hasCandidate(row, col, candidate) {
+					fillRect(img, image.Rect(cx, cy, cx+sub, cy+sub), colorHighlightCandidate)
+				}
+				c := colorCandidate
+				if o.Highlights.isEliminated(row, col, candidate) {
+					c = colorEliminated
+				}
+				drawGlyph(img, o.Symbols.Symbol(candidate), cx+sub/2, cy+sub/2, sub*7/10, c)
+			}
+		}
+	}
+
+	for _, thick := range []bool{false, true} { // thick lines over thin lines
+		for i := 0; i <= s.Length; i++ {
+			pos := margin + i*cell
+			c, half := colorThinLine, 0
+			if thick {
+				c, half = colorThickLine, 1
+			}
+			if ((i % s.BoxWidth) == 0) == thick {
+				fillRect(img, image.Rect(pos-half, margin-half, pos+half+1, size-margin+half+1), c)
+			}
+			if ((i % s.BoxHeight) == 0) == thick {
+				fillRect(img, image.Rect(margin-half, pos-half, size-margin+half+1, pos+half+1), c)
+			}
+		}
+	}
+	return img
+}
+
+// Render returns an error when a symbol of the sudoku has no glyph, which
+// RenderImage leaves out
+func (r PNGRenderer) Render(w io.Writer, s *Solver) error {
+	symbols := r.Options.withDefaults(s).Symbols
+	for value := 1; value <= s.Length; value++ {
+		if _, ok := glyphs[symbols.Symbol(value)]; !ok {
+			return fmt.Errorf("ERROR: No glyph for symbol %q of value %v", symbols.Symbol(value), value)
+		}
+	}
+	return png.Encode(w, RenderImage(s, r.Options))
+}
+
+func fillRect(img *image.RGBA, rect image.Rectangle, c color.RGBA) {
+	draw.Draw(img, rect, &image.Uniform{c}, image.Point{}, draw.Src)
+}
+
+// drawGlyph draws the symbol centered at x, y scaled to the height in pixels
+func drawGlyph(img *image.RGBA, symbol rune, x int, y int, height int, c color.RGBA) {
+	glyph, ok := glyphs[symbol]
+	if !ok {
+		return
+	}
+	scale := max(1, height/glyphHeight)
+	left := x - glyphWidth*scale/2
+	top := y - glyphHeight*scale/2
+	for gy, bits := range glyph {
+		for gx := 0; gx < glyphWidth; gx++ {
+			if bits&(1<<(glyphWidth-1-gx)) == 0 {
+				continue
+			}
+			px := left + gx*scale
+			py := top + gy*scale
+			fillRect(img, image.Rect(px, py, px+scale, py+scale), c)
+		}
+	}
+}
+
+const glyphWidth = 5
+const glyphHeight = 7
+
+// 5x7 bitmap font, every row is a bit mask with the leftmost pixel in bit 4
+var glyphs = map[rune][glyphHeight]uint8{
+	'0': {0x0E, 0x11, 0x13, 0x15, 0x19, 0x11, 0x0E},
+	'1': {0x04, 0x0C, 0x04, 0x04, 0x04, 0x04, 0x0E},
+	'2': {0x0E, 0x11, 0x01, 0x02, 0x04, 0x08, 0x1F},
+	'3': {0x1F, 0x02, 0x04, 0x02, 0x01, 0x11, 0x0E},
+	'4': {0x02, 0x06, 0x0A, 0x12, 0x1F, 0x02, 0x02},
+	'5': {0x1F, 0x10, 0x1E, 0x01, 0x01, 0x11, 0x0E},
+	'6': {0x06, 0x08, 0x10, 0x1E, 0x11, 0x11, 0x0E},
+	'7': {0x1F, 0x01, 0x02, 0x04, 0x08, 0x08, 0x08},
+	'8': {0x0E, 0x11, 0x11, 0x0E, 0x11, 0x11, 0x0E},
+	'9': {0x0E, 0x11, 0x11, 0x0F, 0x01, 0x02, 0x0C},
+	'A': {0x0E, 0x11, 0x11, 0x11, 0x1F, 0x11, 0x11},
+	'B': {0x1E, 0x11, 0x11, 0x1E, 0x11, 0x11, 0x1E},
+	'C': {0x0E, 0x11, 0x10, 0x10, 0x10, 0x11, 0x0E},
+	'D': {0x1C, 0x12, 0x11, 0x11, 0x11, 0x12, 0x1C},
+	'E': {0x1F, 0x10, 0x10, 0x1E, 0x10, 0x10, 0x1F},
+	'F': {0x1F, 0x10, 0x10, 0x1E, 0x10, 0x10, 0x10},
+	'G': {0x0E, 0x11, 0x10, 0x17, 0x11, 0x11, 0x0F},
+	'H': {0x11, 0x11, 0x11, 0x1F, 0x11, 0x11, 0x11},
+	'I': {0x0E, 0x04, 0x04, 0x04, 0x04, 0x04, 0x0E},
+	'J': {0x07, 0x02, 0x02, 0x02, 0x02, 0x12, 0x0C},
+	'K': {0x11, 0x12, 0x14, 0x18, 0x14, 0x12, 0x11},
+	'L': {0x10, 0x10, 0x10, 0x10, 0x10, 0x10, 0x1F},
+	'M': {0x11, 0x1B, 0x15, 0x15, 0x11, 0x11, 0x11},
+	'N': {0x11, 0x11, 0x19, 0x15, 0x13, 0x11, 0x11},
+	'O': {0x0E, 0x11, 0x11, 0x11, 0x11, 0x11, 0x0E},
+	'P': {0x1E, 0x11, 0x11, 0x1E, 0x10, 0x10, 0x10},
+	'Q': {0x0E, 0x11, 0x11, 0x11, 0x15, 0x12, 0x0D},
+	'R': {0x1E, 0x11, 0x11, 0x1E, 0x14, 0x12, 0x11},
+	'S': {0x0F, 0x10, 0x10, 0x0E, 0x01, 0x01, 0x1E},
+	'T': {0x1F, 0x04, 0x04, 0x04, 0x04, 0x04, 0x04},
+	'U': {0x11, 0x11, 0x11, 0x11, 0x11, 0x11, 0x0E},
+	'V': {0x11, 0x11, 0x11, 0x11, 0x11, 0x0A, 0x04},
+	'W': {0x11, 0x11, 0x11, 0x15, 0x15, 0x15, 0x0A},
+	'X': {0x11, 0x11, 0x0A, 0x04, 0x0A, 0x11, 0x11},
+	'Y': {0x11, 0x11, 0x11, 0x0A, 0x04, 0x04, 0x04},
+	'Z': {0x1F, 0x01, 0x02, 0x04, 0x08, 0x10, 0x1F},
+}
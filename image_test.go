@@ -0,0 +1,112 @@
+package solver
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"image/png"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestImage1(t *testing.T) {
+	m, _ := ParseLine("8....7.9..29..4..63..2..........65...174...3.2.........941...7...8..........7...3")
+	s, _ := CheckSudoku(m)
+	UpdateAllCandidates(s)
+	SolveNakedSingle(s)
+
+	highlights := &Highlights{
+		Cells:      []Cell{{Row: 0, Col: 1}},
+		Candidates: []CellCandidate{{Cell: Cell{Row: 0, Col: 2}, Value: 5}},
+	}
+
+	var sb strings.Builder
+	if err := Fprint(&sb, s, SVGRenderer{Options: ImageOptions{Highlights: highlights}}); err != nil {
+		t.Fatalf("error: %v\n", err)
+	}
+	svg := sb.String()
+	if !strings.HasPrefix(svg, "<svg") || !strings.HasSuffix(svg, "</svg>\n") {
+		t.Errorf("invalid svg")
+	}
+	if strings.Count(svg, `font-weight="bold"`) != 23 {
+		t.Errorf("expected 23 givens, got %v", strings.Count(svg, `font-weight="bold"`))
+	}
+	if strings.Count(svg, hexColor(colorHighlightCell)) != 1 || strings.Count(svg, hexColor(colorHighlightCandidate)) != 1 {
+		t.Errorf("highlights not rendered")
+	}
+}
+
+func TestImage2(t *testing.T) {
+	m, _ := ParseLine("1.....2..3.....4")
+	s, _ := CheckSudoku(m)
+	UpdateAllCandidates(s)
+
+	var buf bytes.Buffer
+	options := ImageOptions{CellSize: 40, Highlights: &Highlights{Cells: []Cell{{Row: 3, Col: 0}}}}
+	if err := Fprint(&buf, s, PNGRenderer{Options: options}); err != nil {
+		t.Fatalf("error: %v\n", err)
+	}
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatalf("error: %v\n", err)
+	}
+	margin := imageMargin(&options)
+	size := 4*40 + 2*margin
+	if img.Bounds().Dx() != size || img.Bounds().Dy() != size {
+		t.Errorf("wrong size %v", img.Bounds())
+	}
+	r, g, b, _ := img.At(margin+3, margin+3*40+3).RGBA()
+	if (uint8(r>>8) != colorHighlightCell.R) || (uint8(g>>8) != colorHighlightCell.G) || (uint8(b>>8) != colorHighlightCell.B) {
+		t.Errorf("highlighted cell not rendered")
+	}
+}
+
+func TestImage3(t *testing.T) {
+	// symbols are escaped in the svg
+	m, _ := ParseLine("1.....2..3.....4")
+	s, _ := CheckSudoku(m)
+	UpdateAllCandidates(s)
+	ss, err := NewSymbolSet("markup", "<&>x")
+	if err != nil {
+		t.Fatalf("error: %v\n", err)
+	}
+
+	var sb strings.Builder
+	if err := Fprint(&sb, s, SVGRenderer{Options: ImageOptions{Symbols: ss}}); err != nil {
+		t.Fatalf("error: %v\n", err)
+	}
+	decoder := xml.NewDecoder(strings.NewReader(sb.String()))
+	for {
+		if _, err := decoder.Token(); err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatalf("invalid svg: %v", err)
+		}
+	}
+	if !strings.Contains(sb.String(), "&lt;</text>") || !strings.Contains(sb.String(), "&amp;</text>") {
+		t.Errorf("symbols not escaped")
+	}
+}
+
+func TestImage4(t *testing.T) {
+	// the png has no glyphs of the markup symbols
+	m, _ := ParseLine("1.....2..3.....4")
+	s, _ := CheckSudoku(m)
+	ss, _ := NewSymbolSet("markup", "<&>x")
+
+	var buf bytes.Buffer
+	err := Fprint(&buf, s, PNGRenderer{Options: ImageOptions{Symbols: ss}})
+	fmt.Println(err)
+	if err == nil {
+		t.Errorf("expected error on symbols without glyph")
+	}
+	if buf.Len() != 0 {
+		t.Errorf("png written with missing glyphs")
+	}
+
+	ss, _ = NewSymbolSet("letters", "WXYZ")
+	if err = Fprint(&buf, s, PNGRenderer{Options: ImageOptions{Symbols: ss}}); err != nil {
+		t.Errorf("error: %v\n", err)
+	}
+}
@@ -0,0 +1,101 @@
+package solver
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// ParseError reports an invalid character or length in a textual sudoku.
+// Pos is the zero based character position in the input, Line is the one
+// based line number when the sudoku is read from a multi line source.
+type ParseError struct {
+	Line int
+	Pos  int
+	Row  int
+	Col  int
+	Char rune
+	Msg  string
+}
+
+func (e *ParseError) Error() string {
+	line := ""
+	if e.Line > 0 {
+		line = fmt.Sprintf("line %v, ", e.Line)
+	}
+	if e.Char == 0 {
+		return fmt.Sprintf("ERROR: %v at %vposition %v", e.Msg, line, e.Pos)
+	}
+	return fmt.Sprintf("ERROR: %v %q at %vposition %v (row %v, col %v)", e.Msg, e.Char, line, e.Pos, e.Row, e.Col)
+}
+
+// lineLength returns the side length of the sudoku encoded with n characters
+func lineLength(n int) (int, bool) {
+	length := int(math.Sqrt(float64(n)))
+	if length*length != n {
+		return 0, false
+	}
+	if _, _, ok := BoxSize(length); !ok {
+		return 0, false
+	}
+	return length, true
+}
+
+// ParseLine parses the single line format where cells are listed row by row
+// and blanks are '.', '0' or '_'. The symbol set is detected (see DetectSymbolSet).
+func ParseLine(line string) (*SudokuMatrix, error) {
+	return ParseLineSymbols(line, nil)
+}
+
+// ParseLineSymbols parses the single line format with the symbol set, the
+// symbol set is detected when nil
+func ParseLineSymbols(line string, symbols *SymbolSet) (*SudokuMatrix, error) {
+	line = strings.TrimSpace(line)
+	cells := []rune(line)
+	length, ok := lineLength(len(cells))
+	if !ok {
+		return nil, &ParseError{Pos: len(cells), Msg: fmt.Sprintf("Invalid sudoku length %v", len(cells))}
+	}
+	if symbols == nil {
+		symbols = DetectSymbolSet(line, length)
+	}
+
+	m := SudokuMatrix{Sudoku: make([][]int, length)}
+	for r := range m.Sudoku {
+		m.Sudoku[r] = make([]int, length)
+		for c := range m.Sudoku[r] {
+			pos := r*length + c
+			ch := cells[pos]
+			if symbols.IsBlank(ch) {
+				continue
+			}
+			value, ok := symbols.Value(ch)
+			if !ok || value > length {
+				return nil, &ParseError{Pos: pos, Row: r, Col: c, Char: ch, Msg: "Invalid character"}
+			}
+			m.Sudoku[r][c] = value
+		}
+	}
+	return &m, nil
+}
+
+// FormatLine returns the single line format of the sudoku with '.' for blanks
+// and the default symbol set
+func FormatLine(m *SudokuMatrix) string {
+	return FormatLineSymbols(m, nil)
+}
+
+func FormatLineSymbols(m *SudokuMatrix, symbols *SymbolSet) string {
+	symbols = symbolsOrDefault(symbols, len(m.Sudoku))
+	var sb strings.Builder
+	for _, row := range m.Sudoku {
+		for _, value := range row {
+			if value == 0 {
+				sb.WriteString(NullValue)
+			} else {
+				sb.WriteRune(symbols.Symbol(value))
+			}
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,108 @@
+package solver
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestLineFormat1(t *testing.T) {
+	line := "8....7.9." + ".29..4..6" + "3..2....." + ".....65.." + ".174...3." + "2........" + ".941...7." + "..8......" + "....7...3"
+	m, err := ParseLine(line)
+	if err != nil {
+		t.Fatalf("error: %v\n", err)
+	}
+	if m.Sudoku[0][0] != 8 || m.Sudoku[0][5] != 7 || m.Sudoku[8][8] != 3 {
+		t.Errorf("wrong values parsed: %v", m.Sudoku)
+	}
+	if FormatLine(m) != line {
+		t.Errorf("expected %v, got %v", line, FormatLine(m))
+	}
+
+	blanks := strings.NewReplacer(".", "0").Replace(line)
+	m, err = ParseLine(blanks)
+	if err != nil {
+		t.Fatalf("error: %v\n", err)
+	}
+	if FormatLine(m) != line {
+		t.Errorf("expected %v, got %v", line, FormatLine(m))
+	}
+}
+
+func TestLineFormat2(t *testing.T) {
+	line := "1_3_" + "____" + "____" + "___1"
+	m, err := ParseLine(line)
+	if err != nil {
+		t.Fatalf("error: %v\n", err)
+	}
+	if len(m.Sudoku) != 4 || m.Sudoku[3][3] != 1 {
+		t.Errorf("wrong values parsed: %v", m.Sudoku)
+	}
+
+	line = "AbCDEFG" + strings.Repeat(".", 249)
+	m, err = ParseLine(line)
+	if err != nil {
+		t.Fatalf("error: %v\n", err)
+	}
+	if m.Sudoku[0][0] != 10 || m.Sudoku[0][1] != 11 || m.Sudoku[0][6] != 16 {
+		t.Errorf("wrong values parsed: %v", m.Sudoku[0])
+	}
+	if FormatLine(m) != strings.ToUpper(line) {
+		t.Errorf("expected %v, got %v", strings.ToUpper(line), FormatLine(m))
+	}
+
+	m, err = ParseLine("P" + strings.Repeat(".", 624))
+	if err != nil {
+		t.Fatalf("error: %v\n", err)
+	}
+	if m.Sudoku[0][0] != 25 {
+		t.Errorf("wrong values parsed: %v", m.Sudoku[0])
+	}
+}
+
+func TestLineFormat3(t *testing.T) {
+	var perr *ParseError
+
+	_, err := ParseLine(strings.Repeat(".", 80))
+	fmt.Println(err)
+	if !errors.As(err, &perr) || perr.Pos != 80 {
+		t.Errorf("expected length error, got %v", err)
+	}
+
+	_, err = ParseLine(strings.Repeat(".", 12) + "x" + strings.Repeat(".", 68))
+	fmt.Println(err)
+	if !errors.As(err, &perr) || perr.Pos != 12 || perr.Row != 1 || perr.Col != 3 || perr.Char != 'x' {
+		t.Errorf("expected character error, got %v", err)
+	}
+
+	_, err = ParseLine("5" + strings.Repeat(".", 15))
+	fmt.Println(err)
+	if !errors.As(err, &perr) || perr.Pos != 0 {
+		t.Errorf("expected character error, got %v", err)
+	}
+}
+
+func TestLineFormat4(t *testing.T) {
+	// '0' and '.' blanks mixed in 9x9 without 9 and in 4x4 are not hexadecimal
+	line := "8....7.0." + ".2...4..6" + "3..2..0.." + "....065.." + ".174...3." + "2......0." + "..41...7." + "..8...0.." + "....7...3"
+	m, err := ParseLine(line)
+	if err != nil {
+		t.Fatalf("error: %v\n", err)
+	}
+	fmt.Println(m.Sudoku[0])
+	if m.Sudoku[0][0] != 8 || m.Sudoku[0][5] != 7 || m.Sudoku[0][7] != 0 || m.Sudoku[4][2] != 7 || m.Sudoku[8][8] != 3 {
+		t.Errorf("wrong values parsed: %v", m.Sudoku)
+	}
+	if FormatLine(m) != strings.ReplaceAll(line, "0", ".") {
+		t.Errorf("expected %v, got %v", line, FormatLine(m))
+	}
+
+	m, err = ParseLine("1.0.2...........")
+	if err != nil {
+		t.Fatalf("error: %v\n", err)
+	}
+	if m.Sudoku[0][0] != 1 || m.Sudoku[0][2] != 0 || m.Sudoku[1][0] != 2 {
+		t.Errorf("wrong values parsed: %v", m.Sudoku)
+	}
+}
@@ -0,0 +1,137 @@
+package solver
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+)
+
+// EventKind is the kind of a solver event
+type EventKind int
+
+const (
+	EventStrategyStart EventKind = iota // strategy is applied
+	EventStrategyEnd                    // strategy is finished, see Updated and Solved
+	EventPlacement                      // value is placed in the cell
+	EventElimination                    // candidates are removed from the cell
+	EventGuess                          // search tries the value in the cell
+	EventBacktrack                      // search clears the cell, no guess leads to a solution
+)
+
+func (k EventKind) String() string {
+	switch k {
+	case EventStrategyStart:
+		return "strategy start"
+	case EventStrategyEnd:
+		return "strategy end"
+	case EventPlacement:
+		return "placement"
+	case EventElimination:
+		return "elimination"
+	case EventGuess:
+		return "guess"
+	case EventBacktrack:
+		return "backtrack"
+	}
+	return fmt.Sprintf("EventKind(%d)", int(k))
+}
+
+// Event is sent to the Observer while solving, only the fields of the kind are set
+type Event struct {
+	Kind       EventKind
+	Strategy   string       // strategy of the event
+	Cell       Cell         // cell of placements, eliminations, guesses and backtracks
+	Value      int          // placed or guessed value
+	Candidates CandidateSet // removed candidates of eliminations
+	Depth      int          // search depth of guesses and backtracks
+	Updated    bool         // strategy end: the strategy changed values or candidates
+	Solved     bool         // strategy end: the sudoku is solved
+}
+
+// Observer receives the events of the solver, set with SolveOptions.Observer
+type Observer interface {
+	Observe(e Event)
+}
+
+// ObserverFunc is a function used as Observer
+type ObserverFunc func(e Event)
+
+func (f ObserverFunc) Observe(e Event) {
+	f(e)
+}
+
+// SlogObserver logs every event as a record of the logger at the level
+type SlogObserver struct {
+	Logger *slog.Logger
+	Level  slog.Level
+}
+
+// NewSlogObserver returns the observer logging at the debug level, the default
+// logger is used for a nil logger
+func NewSlogObserver(logger *slog.Logger) *SlogObserver {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &SlogObserver{Logger: logger, Level: slog.LevelDebug}
+}
+
+func (o *SlogObserver) Observe(e Event) {
+	ctx := context.Background()
+	if !o.Logger.Enabled(ctx, o.Level) {
+		return
+	}
+	attrs := make([]slog.Attr, 0, 5)
+	if e.Strategy != "" {
+		attrs = append(attrs, slog.String("strategy", e.Strategy))
+	}
+	switch e.Kind {
+	case EventStrategyEnd:
+		attrs = append(attrs, slog.Bool("updated", e.Updated), slog.Bool("solved", e.Solved))
+	case EventPlacement:
+		attrs = append(attrs, slog.Int("row", e.Cell.Row), slog.Int("col", e.Cell.Col), slog.Int("value", e.Value))
+	case EventElimination:
+		attrs = append(attrs, slog.Int("row", e.Cell.Row), slog.Int("col", e.Cell.Col), slog.String("candidates", e.Candidates.String()))
+	case EventGuess:
+		attrs = append(attrs, slog.Int("row", e.Cell.Row), slog.Int("col", e.Cell.Col), slog.Int("value", e.Value), slog.Int("depth", e.Depth))
+	case EventBacktrack:
+		attrs = append(attrs, slog.Int("row", e.Cell.Row), slog.Int("col", e.Cell.Col), slog.Int("depth", e.Depth))
+	}
+	o.Logger.LogAttrs(ctx, o.Level, e.Kind.String(), attrs...)
+}
+
+// notify sends the event of the current strategy to the observer of the solver
+func (s *Solver) notify(e Event) {
+	if s.observer == nil {
+		return
+	}
+	e.Strategy = s.strategy
+	s.observer.Observe(e)
+}
+
+// eliminate removes the candidates from the cell, it returns whether any was removed
+func (s *Solver) eliminate(row int, col int, candidates CandidateSet) bool {
+	removed := s.Candidates[row][col].Intersect(candidates)
+	if removed == 0 {
+		return false
+	}
+	s.Candidates[row][col] = s.Candidates[row][col].Minus(removed)
+	s.notify(Event{Kind: EventElimination, Cell: Cell{Row: row, Col: col}, Candidates: removed})
+	return true
+}
+
+// place sets the value of the cell and clears its candidates
+func (s *Solver) place(row int, col int, value int) {
+	s.Problem.Sudoku[row][col] = value
+	s.Candidates[row][col] = 0
+	s.notify(Event{Kind: EventPlacement, Cell: Cell{Row: row, Col: col}, Value: value})
+}
+
+// applyStrategy runs the strategy between the start and end events
+func applyStrategy(s *Solver, name string, strategy func(s *Solver) (bool, bool)) (bool, bool) {
+	s.strategy = name
+	s.notify(Event{Kind: EventStrategyStart})
+	updated, solved := strategy(s)
+	s.notify(Event{Kind: EventStrategyEnd, Updated: updated, Solved: solved})
+	s.strategy = ""
+	return updated, solved
+}
@@ -0,0 +1,112 @@
+package solver
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestObserver1(t *testing.T) {
+	m, _ := ParseLine(easySudoku)
+	s, _ := CheckSudoku(m)
+	empty := len(s.Grid().EmptyCells())
+
+	counts := make(map[EventKind]int)
+	solved, err := SolveWithOptions(s, SolveOptions{Observer: ObserverFunc(func(e Event) {
+		counts[e.Kind]++
+		if e.Strategy == "" {
+			t.Errorf("%v without strategy", e.Kind)
+		}
+		if (e.Kind == EventPlacement) && (s.Problem.Sudoku[e.Cell.Row][e.Cell.Col] != e.Value) {
+			t.Errorf("placement %v not in [%v, %v]", e.Value, e.Cell.Row, e.Cell.Col)
+		}
+	})})
+	if err != nil || !solved {
+		t.Fatalf("sudoku not solved: %v", err)
+	}
+	if counts[EventPlacement] != empty {
+		t.Errorf("expected %v placements, got %v", empty, counts[EventPlacement])
+	}
+	if (counts[EventStrategyStart] == 0) || (counts[EventStrategyStart] != counts[EventStrategyEnd]) {
+		t.Errorf("unbalanced strategies: %v starts, %v ends", counts[EventStrategyStart], counts[EventStrategyEnd])
+	}
+	if counts[EventElimination] == 0 {
+		t.Errorf("no eliminations")
+	}
+	if counts[EventGuess] != 0 {
+		t.Errorf("unexpected guesses: %v", counts[EventGuess])
+	}
+}
+
+func TestObserver2(t *testing.T) {
+	m, _ := ParseLine(hardSudoku)
+	s, _ := CheckSudoku(m)
+
+	depth := 0
+	counts := make(map[EventKind]int)
+	solved, err := SolveWithOptions(s, SolveOptions{Backend: BackendDepthFirstSearch, Observer: ObserverFunc(func(e Event) {
+		counts[e.Kind]++
+		switch e.Kind {
+		case EventGuess:
+			depth = e.Depth
+		case EventBacktrack:
+			if e.Depth > depth+1 {
+				t.Fatalf("backtrack at depth %v after guess at depth %v", e.Depth, depth)
+			}
+			depth = e.Depth - 1
+		}
+	})})
+	if err != nil || !solved {
+		t.Fatalf("sudoku not solved: %v", err)
+	}
+	if (counts[EventGuess] == 0) || (counts[EventBacktrack] == 0) {
+		t.Errorf("expected guesses and backtracks, got %v", counts)
+	}
+	if s.observer != nil {
+		t.Errorf("observer not removed from the solver")
+	}
+}
+
+func TestObserver3(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	m, _ := ParseLine(easySudoku)
+	s, _ := CheckSudoku(m)
+	if solved, err := SolveWithOptions(s, SolveOptions{Observer: NewSlogObserver(logger)}); err != nil || !solved {
+		t.Fatalf("sudoku not solved: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	var first struct {
+		Msg      string
+		Strategy string
+	}
+	if err := json.Unmarshal([]byte(lines[0]), &first); err != nil {
+		t.Fatalf("invalid record %q: %v", lines[0], err)
+	}
+	if (first.Msg != "strategy start") || (first.Strategy != "NakedSingle") {
+		t.Errorf("unexpected first record %q", lines[0])
+	}
+	placements := 0
+	for _, line := range lines {
+		if strings.Contains(line, `"msg":"placement"`) && strings.Contains(line, `"value":`) {
+			placements++
+		}
+	}
+	if placements != 49 {
+		t.Errorf("expected 49 placements, got %v", placements)
+	}
+
+	// records below the level of the logger are skipped
+	buf.Reset()
+	quiet := slog.New(slog.NewJSONHandler(&buf, nil))
+	m, _ = ParseLine(easySudoku)
+	s, _ = CheckSudoku(m)
+	SolveWithOptions(s, SolveOptions{Observer: NewSlogObserver(quiet)})
+	if buf.Len() != 0 {
+		t.Errorf("unexpected records %q", buf.String())
+	}
+}
@@ -0,0 +1,163 @@
+package solver
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// ReadPencilMarks reads a candidate grid as posted on puzzle forums:
+//
+//	.----------------.----------------.
+//	| 8    456  156  | 356  1356 7    |
+//	:----------------+----------------:
+//
+// Every cell lists its candidates, a cell with a single symbol is a placed
+// value (an unsolved cell with a single candidate is written the same way).
+// Borders and box separator lines are ignored, the symbol set is detected.
+func ReadPencilMarks(r io.Reader) (*Solver, error) {
+	rows := make([][]string, 0, 9)
+	rowLines := make([]int, 0, 9)
+
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") || isSeparatorLine(text) {
+			continue
+		}
+		rows = append(rows, strings.Fields(strings.ReplaceAll(text, "|", " ")))
+		rowLines = append(rowLines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	length := len(rows)
+	if _, _, ok := BoxSize(length); !ok {
+		return nil, &ParseError{Line: line, Msg: fmt.Sprintf("Invalid number of rows %v", length)}
+	}
+
+	tokens := make([]string, 0, length)
+	for _, row := range rows {
+		tokens = append(tokens, row...)
+	}
+	symbols := DetectSymbolSet(strings.Join(tokens, " "), length)
+
+	m := SudokuMatrix{Sudoku: make([][]int, length)}
+	candidates := newCandidateSets(length)
+	for r, row := range rows {
+		if len(row) != length {
+			return nil, &ParseError{Line: rowLines[r], Pos: len(row), Msg: fmt.Sprintf("Invalid number of cells %v", len(row))}
+		}
+		m.Sudoku[r] = make([]int, length)
+		for c, token := range row {
+			var cellCandidates CandidateSet
+			last := 0
+			for _, ch := range token {
+				if symbols.IsBlank(ch) {
+					continue
+				}
+				value, ok := symbols.Value(ch)
+				if !ok || value > length {
+					return nil, &ParseError{Line: rowLines[r], Row: r, Col: c, Char: ch, Msg: "Invalid character"}
+				}
+				if last >= value {
+					return nil, &ParseError{Line: rowLines[r], Row: r, Col: c, Char: ch, Msg: "Unsorted or duplicate candidate"}
+				}
+				cellCandidates = cellCandidates.Add(value)
+				last = value
+			}
+			if cellCandidates.Count() == 1 && utf8.RuneCountInString(token) == 1 {
+				m.Sudoku[r][c] = last
+				cellCandidates = 0
+			}
+			candidates[r][c] = cellCandidates
+		}
+	}
+
+	s, err := CheckSudoku(&m)
+	if err != nil {
+		return nil, err
+	}
+	s.Candidates = candidates
+	return s, nil
+}
+
+func candidatesText(s *Solver, row int, col int, symbols *SymbolSet) string {
+	if value := s.Problem.Sudoku[row][col]; value != 0 {
+		return string(symbols.Symbol(value))
+	}
+	if (s.Candidates == nil) || (s.Candidates[row][col] == 0) {
+		return NullValue
+	}
+	var sb strings.Builder
+	for _, candidate := range s.Candidates[row][col].Values() {
+		sb.WriteRune(symbols.Symbol(candidate))
+	}
+	return sb.String()
+}
+
+// WritePencilMarks writes placed values and candidates of all cells in the
+// layout read by ReadPencilMarks with the default symbol set
+func WritePencilMarks(w io.Writer, s *Solver) error {
+	return writePencilMarks(w, s, DefaultSymbolSet(s.Length))
+}
+
+func writePencilMarks(w io.Writer, s *Solver, symbols *SymbolSet) error {
+	return writeCandidateGrid(w, s,
+		func(row int, col int) string { return candidatesText(s, row, col, symbols) },
+		func(row int, col int, padded string) string { return padded })
+}
+
+// writeCandidateGrid writes the pencil mark layout of cell texts, every cell
+// text is padded to the column width and then decorated
+func writeCandidateGrid(w io.Writer, s *Solver, text func(row int, col int) string, decorate func(row int, col int, padded string) string) error {
+	widths := make([]int, s.Length)
+	texts := make([][]string, s.Length)
+	for r := 0; r < s.Length; r++ {
+		texts[r] = make([]string, s.Length)
+		for c := 0; c < s.Length; c++ {
+			texts[r][c] = text(r, c)
+			widths[c] = max(widths[c], utf8.RuneCountInString(texts[r][c]))
+		}
+	}
+
+	separator := func(left string, middle string, right string) string {
+		var sb strings.Builder
+		sb.WriteString(left)
+		for c := 0; c < s.Length; c += s.BoxWidth {
+			if c != 0 {
+				sb.WriteString(middle)
+			}
+			blockWidth := 1
+			for i := c; i < c+s.BoxWidth; i++ {
+				blockWidth += widths[i] + 1
+			}
+			sb.WriteString(strings.Repeat("-", blockWidth))
+		}
+		sb.WriteString(right + "\n")
+		return sb.String()
+	}
+
+	bw := bufio.NewWriter(w)
+	bw.WriteString(separator(".", ".", "."))
+	for r := 0; r < s.Length; r++ {
+		if (r != 0) && (r%s.BoxHeight) == 0 {
+			bw.WriteString(separator(":", "+", ":"))
+		}
+		for c := 0; c < s.Length; c++ {
+			if (c % s.BoxWidth) == 0 {
+				bw.WriteString("| ")
+			}
+			padded := texts[r][c] + strings.Repeat(" ", widths[c]-utf8.RuneCountInString(texts[r][c]))
+			bw.WriteString(decorate(r, c, padded) + " ")
+		}
+		bw.WriteString("|\n")
+	}
+	bw.WriteString(separator("'", "'", "'"))
+	return bw.Flush()
+}
@@ -0,0 +1,128 @@
+package solver
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestPencilMarks1(t *testing.T) {
+	m, _ := ParseLine("8....7.9..29..4..63..2..........65...174...3.2.........941...7...8..........7...3")
+	s, _ := CheckSudoku(m)
+	UpdateAllCandidates(s)
+	SolveNakedPair(s)
+	PrintCandidates(s)
+
+	var sb strings.Builder
+	if err := WritePencilMarks(&sb, s); err != nil {
+		t.Fatalf("error: %v\n", err)
+	}
+	s2, err := ReadPencilMarks(strings.NewReader(sb.String()))
+	if err != nil {
+		t.Fatalf("error: %v\n", err)
+	}
+	for r := range s.Candidates {
+		for c := range s.Candidates[r] {
+			if (s.Candidates[r][c].Count() == 1) && (s2.Problem.Sudoku[r][c] == s.Candidates[r][c].Min()) {
+				continue // a single candidate is read as placed value
+			}
+			if s.Problem.Sudoku[r][c] != s2.Problem.Sudoku[r][c] {
+				t.Errorf("placed value not restored in [%v, %v]", r, c)
+			}
+			if s.Candidates[r][c].Count() != s2.Candidates[r][c].Count() {
+				t.Errorf("candidates not restored in [%v, %v]: %v, %v", r, c, s.Candidates[r][c], s2.Candidates[r][c])
+			}
+		}
+	}
+}
+
+func TestPencilMarks2(t *testing.T) {
+	input := `
+.-----------.-----------.
+| 1   234 | 24  3   |
+| 34  24  | 1   24  |
+:-----------+-----------:
+| 2   13  | 34  14  |
+| 34  134 | 2   1   |
+'-----------'-----------'
+`
+	s, err := ReadPencilMarks(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("error: %v\n", err)
+	}
+	if s.Problem.Sudoku[0][0] != 1 || s.Problem.Sudoku[3][3] != 1 || s.Problem.Sudoku[0][1] != 0 {
+		t.Errorf("wrong placed values: %v", s.Problem.Sudoku)
+	}
+	if s.Candidates[3][1] != NewCandidateSet(1, 3, 4) {
+		t.Errorf("wrong candidates: %v", s.Candidates[3][1])
+	}
+
+	_, err = ReadPencilMarks(strings.NewReader(strings.Replace(input, "134", "174", 1)))
+	fmt.Println(err)
+	if err == nil {
+		t.Errorf("expected error on invalid candidate")
+	}
+}
+
+func TestPencilMarks3(t *testing.T) {
+	// the loaded candidates are kept by Solve with KeepCandidates
+	m, _ := ParseLine("8....7.9..29..4..63..2..........65...174...3.2.........941...7...8..........7...3")
+	s, _ := CheckSudoku(m)
+	UpdateAllCandidates(s)
+	SolveNakedPair(s)
+	var sb strings.Builder
+	WritePencilMarks(&sb, s)
+
+	differs := func(s2 *Solver) bool {
+		for r := range s2.Candidates {
+			for c := range s2.Candidates[r] {
+				if (s2.Problem.Sudoku[r][c] == 0) && (s2.Candidates[r][c] != s.Candidates[r][c]) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	options := SolveOptions{Pipeline: []Strategy{}, NoSearch: true}
+
+	s2, _ := ReadPencilMarks(strings.NewReader(sb.String()))
+	options.KeepCandidates = true
+	if _, err := SolveWithOptions(s2, options); err != nil {
+		t.Fatalf("error: %v\n", err)
+	}
+	if differs(s2) {
+		t.Errorf("loaded candidates not kept")
+	}
+
+	s2, _ = ReadPencilMarks(strings.NewReader(sb.String()))
+	options.KeepCandidates = false
+	if _, err := SolveWithOptions(s2, options); err != nil {
+		t.Fatalf("error: %v\n", err)
+	}
+	if !differs(s2) {
+		t.Errorf("candidates not recalculated")
+	}
+}
+
+func TestPencilMarks4(t *testing.T) {
+	// dotless i is the letter I, a single symbol of two bytes
+	var sb strings.Builder
+	for r := 0; r < 9; r++ {
+		for c := 0; c < 9; c++ {
+			if (r == 4) && (c == 4) {
+				sb.WriteString("ı ")
+			} else {
+				sb.WriteString("ABCDEFGHI ")
+			}
+		}
+		sb.WriteString("\n")
+	}
+
+	s, err := ReadPencilMarks(strings.NewReader(sb.String()))
+	if err != nil {
+		t.Fatalf("error: %v\n", err)
+	}
+	if (s.Problem.Sudoku[4][4] != 9) || (s.Candidates[4][4] != 0) {
+		t.Errorf("expected value 9, got %v %v", s.Problem.Sudoku[4][4], s.Candidates[4][4])
+	}
+}
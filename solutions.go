@@ -0,0 +1,62 @@
+package solver
+
+// CountSolutions counts the solutions of the sudoku and stops once limit
+// solutions are found, all solutions are counted when limit is 0 or less.
+// The first two solutions are returned too, so the cells where an ambiguous
+// sudoku differs are shown with DiffCells. The solver is not changed.
+func CountSolutions(s *Solver, limit int) (int, []SudokuMatrix, error) {
+	solutions := make([]SudokuMatrix, 0, 2)
+	count := 0
+	_, err := EnumerateDLX(s, func(solution *SudokuMatrix) bool {
+		count++
+		if len(solutions) < 2 {
+			solutions = append(solutions, *solution)
+		}
+		return (limit <= 0) || (count < limit)
+	})
+	return count, solutions, err
+}
+
+// HasUniqueSolution reports whether the sudoku has exactly one solution, the
+// search stops at the second solution. The solutions are returned: one for
+// a unique solution, two differing solutions for an ambiguous sudoku and none
+// for an unsolvable sudoku.
+func HasUniqueSolution(s *Solver) (bool, []SudokuMatrix, error) {
+	count, solutions, err := CountSolutions(s, 2)
+	if err != nil {
+		return false, nil, err
+	}
+	return count == 1, solutions, nil
+}
+
+// DiffCells returns the cells with different values in row by row order
+func DiffCells(a *SudokuMatrix, b *SudokuMatrix) []Cell {
+	cells := make([]Cell, 0)
+	for r, row := range a.Sudoku {
+		for c, value := range row {
+			if value != b.Sudoku[r][c] {
+				cells = append(cells, Cell{Row: r, Col: c})
+			}
+		}
+	}
+	return cells
+}
+
+// EnumerateSolutions calls visit with every solution of the sudoku found by
+// Depth First Search until visit returns false. Every solution is a new copy
+// that the caller may keep. It returns the number of visited solutions, the
+// solver is not changed.
+func EnumerateSolutions(s *Solver, visit func(solution *SudokuMatrix) bool) (int, error) {
+	if !s.validated {
+		return 0, ErrNotValidated
+	}
+	work := *s
+	work.Problem = copyMatrix(&s.Problem)
+	count := 0
+	depthFirstSearch(&work, 0, 0, 1, nil, func() bool {
+		count++
+		solution := copyMatrix(&work.Problem)
+		return visit(&solution)
+	})
+	return count, nil
+}
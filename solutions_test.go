@@ -0,0 +1,99 @@
+package solver
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSolutions1(t *testing.T) {
+	m, _ := ParseLine("1.....2..3.....4")
+	s, _ := CheckSudoku(m)
+	unique, solutions, err := HasUniqueSolution(s)
+	if err != nil || !unique || len(solutions) != 1 {
+		t.Fatalf("expected unique solution, got %v %v %v", unique, len(solutions), err)
+	}
+	if FormatLine(&s.Problem) != "1.....2..3.....4" {
+		t.Errorf("solver changed by HasUniqueSolution")
+	}
+
+	// the 2 and 3 in the first two cols of the last two rows can be swapped
+	m, _ = ParseLine("12343412........")
+	s, _ = CheckSudoku(m)
+	unique, solutions, err = HasUniqueSolution(s)
+	if err != nil || unique || len(solutions) != 2 {
+		t.Fatalf("expected two solutions, got %v %v %v", unique, len(solutions), err)
+	}
+	cells := DiffCells(&solutions[0], &solutions[1])
+	if len(cells) == 0 {
+		t.Errorf("expected different solutions")
+	}
+	for _, cell := range cells {
+		if m.Sudoku[cell.Row][cell.Col] != 0 {
+			t.Errorf("given differs in %v", cell)
+		}
+	}
+}
+
+func TestSolutions2(t *testing.T) {
+	m := SudokuMatrix{Sudoku: [][]int{{0, 0, 0, 0}, {0, 0, 0, 0}, {0, 0, 0, 0}, {0, 0, 0, 0}}}
+	s, _ := CheckSudoku(&m)
+	for _, test := range []struct{ limit, count int }{{0, 288}, {-1, 288}, {1, 1}, {5, 5}, {300, 288}} {
+		count, solutions, err := CountSolutions(s, test.limit)
+		if err != nil || count != test.count || len(solutions) != min(count, 2) {
+			t.Errorf("limit %v: expected %v solutions, got %v %v %v", test.limit, test.count, count, len(solutions), err)
+		}
+	}
+
+	unsolvable, _ := ParseLine(".1...3.6.....9.2..548.6.......9...2...7...5..1...5........8.45...9.4.8...2.6...93")
+	s, _ = CheckSudoku(unsolvable)
+	count, solutions, err := CountSolutions(s, 0)
+	if err != nil || count != 0 || len(solutions) != 0 {
+		t.Errorf("expected no solution, got %v %v %v", count, len(solutions), err)
+	}
+	unique, _, err := HasUniqueSolution(s)
+	if err != nil || unique {
+		t.Errorf("expected no unique solution, got %v %v", unique, err)
+	}
+
+	s.validated = false
+	if _, _, err = HasUniqueSolution(s); !errors.Is(err, ErrNotValidated) {
+		t.Errorf("expected %v, got %v", ErrNotValidated, err)
+	}
+}
+
+func TestSolutions3(t *testing.T) {
+	m := SudokuMatrix{Sudoku: [][]int{{0, 0, 0, 0}, {0, 0, 0, 0}, {0, 0, 0, 0}, {0, 0, 0, 0}}}
+	s, _ := CheckSudoku(&m)
+	seen := make(map[string]bool)
+	solutions := make([]*SudokuMatrix, 0)
+	count, err := EnumerateSolutions(s, func(solution *SudokuMatrix) bool {
+		seen[FormatLine(solution)] = true
+		solutions = append(solutions, solution)
+		return true
+	})
+	if err != nil || count != 288 || len(seen) != 288 {
+		t.Fatalf("expected 288 different solutions, got %v %v %v", count, len(seen), err)
+	}
+	for _, solution := range solutions {
+		if err = Validate(solution); err != nil {
+			t.Fatalf("solution not valid: %v", err)
+		}
+	}
+	if FormatLine(&s.Problem) != "................" {
+		t.Errorf("solver changed by EnumerateSolutions")
+	}
+
+	count, _ = EnumerateSolutions(s, func(solution *SudokuMatrix) bool { return false })
+	if count != 1 || FormatLine(&s.Problem) != "................" {
+		t.Errorf("expected to stop at the first solution, got %v", count)
+	}
+
+	// the same solutions as Dancing Links
+	m6, _ := ParseLine("1..4.." + "....6." + "3....." + "......" + "..2..." + "......")
+	s6, _ := CheckSudoku(m6)
+	dfs, _ := EnumerateSolutions(s6, func(solution *SudokuMatrix) bool { return true })
+	dlx, _ := EnumerateDLX(s6, nil)
+	if dfs != dlx || dfs < 2 {
+		t.Errorf("expected the same number of solutions, got %v and %v", dfs, dlx)
+	}
+}
@@ -1,9 +1,11 @@
 package solver
 
 import (
+	"context"
 	"fmt"
 )
 
+// IsInRowC checks the slice view of candidates (see Solver.CandidateSlices)
 func IsInRowC(m *[][][]int, row int, col int, search int) bool {
 	for c, cRow := range (*m)[row] {
 		if c == col {
@@ -30,6 +32,7 @@ func IsInRow(s *Solver, row int, search int) bool {
 	return false
 }
 
+// IsInColC checks the slice view of candidates (see Solver.CandidateSlices)
 func IsInColC(m *[][][]int, row int, col int, search int) bool {
 	for r, cRow := range *m {
 		for c, candidate := range cRow[col] {
@@ -56,25 +59,24 @@ func IsInCol(s *Solver, col int, search int) bool {
 	return false
 }
 
+// IsInBlockC checks the slice view of candidates (see Solver.CandidateSlices)
+// in square blocks with the side dim
 func IsInBlockC(m *[][][]int, dim int, row int, col int, search int) bool {
+	return IsInBlockBoxesC(m, dim, dim, row, col, search)
+}
 
-	blockRowStart := (row / dim) * dim
-	blockRowEnd := blockRowStart + dim
-	blockColStart := (col / dim) * dim
-	blockColEnd := blockColStart + dim
-
-	for r := blockRowStart; r < blockRowEnd; r++ {
-		for c := blockColStart; c < blockColEnd; c++ {
-			if (r == row) && (c == col) {
-				continue
+// IsInBlockBoxesC is IsInBlockC for blocks of boxHeight rows and boxWidth cols
+func IsInBlockBoxesC(m *[][][]int, boxHeight int, boxWidth int, row int, col int, search int) bool {
+	for _, cell := range layoutOf(len(*m), boxHeight, boxWidth).block(row, col).Cells {
+		if (cell.Row == row) && (cell.Col == col) {
+			continue
+		}
+		for _, candidate := range (*m)[cell.Row][cell.Col] {
+			if candidate > search {
+				break
 			}
-			for _, candidate := range (*m)[r][c] {
-				if candidate > search {
-					break
-				}
-				if search == candidate {
-					return true
-				}
+			if search == candidate {
+				return true
 			}
 		}
 	}
@@ -82,138 +84,90 @@ func IsInBlockC(m *[][][]int, dim int, row int, col int, search int) bool {
 }
 
 func IsInBlock(s *Solver, row int, col int, search int, excludeRowsCols bool) bool {
-	dim := s.Dim
-
-	blockRowStart := (row / dim) * dim
-	blockRowEnd := blockRowStart + dim
-	blockColStart := (col / dim) * dim
-	blockColEnd := blockColStart + dim
-
-	for r := blockRowStart; r < blockRowEnd; r++ {
-		if excludeRowsCols && (r == row) {
+	for _, cell := range solverLayout(s).block(row, col).Cells {
+		if excludeRowsCols && ((cell.Row == row) || (cell.Col == col)) {
 			continue
 		}
-		for c := blockColStart; c < blockColEnd; c++ {
-			if excludeRowsCols && (c == col) {
-				continue
-			}
-			if search == s.Problem.Sudoku[r][c] {
-				return true
-			}
+		if search == s.Problem.Sudoku[cell.Row][cell.Col] {
+			return true
 		}
 	}
 	return false
 }
 
-func getRowColCandidates(s *Solver, row int, col int) *[]int {
-	candidates := make([]int, 0, s.Length)
-	for i := 1; i <= s.Length; i++ { // add candidates in sorted order (ascending)
-		if IsInRow(s, row, i) || IsInCol(s, col, i) || IsInBlock(s, row, col, i, true) {
-			continue
-		} else {
-			candidates = append(candidates, i)
-		}
+// rowColCandidates returns the values not placed in the row, col and block of the cell
+func rowColCandidates(s *Solver, row int, col int) CandidateSet {
+	var placed CandidateSet
+	for _, peer := range solverLayout(s).peers[row*s.Length+col] {
+		placed = placed.Add(s.Problem.Sudoku[peer.Row][peer.Col])
 	}
-	return &candidates
+	return AllCandidates(s.Length).Minus(placed)
 }
 
-func UpdateAllCandidates(s *Solver) {
-	s.Candidates = make([][][]int, s.Length)
-	for r := range s.Candidates {
-		s.Candidates[r] = make([][]int, s.Length)
-		for c := range s.Candidates[r] {
-			s.Candidates[r][c] = make([]int, 0, s.Length)
-		}
+func UpdateAllCandidates(s *Solver) error {
+	if !s.validated {
+		return ErrNotValidated
 	}
+	s.Candidates = newCandidateSets(s.Length)
 
 	for r := 0; r < s.Length; r++ {
 		for c := 0; c < s.Length; c++ {
 			if s.Problem.Sudoku[r][c] != 0 {
 				continue
 			}
-			for i := 1; i <= s.Length; i++ { // add candidates in sorted order (ascending)
-				if IsInRow(s, r, i) || IsInCol(s, c, i) || IsInBlock(s, r, c, i, true) {
-					continue
-				} else {
-					s.Candidates[r][c] = append(s.Candidates[r][c], i)
-				}
-			}
-			if len(s.Candidates[r][c]) == 0 {
-				panic("Sudoku is unsolvable")
+			s.Candidates[r][c] = rowColCandidates(s, r, c)
+			if s.Candidates[r][c] == 0 {
+				return &UnsolvableError{Cell{Row: r, Col: c}}
 			}
 		}
 	}
+	return nil
 }
 
 func UpdateCandidates(s *Solver, row int, col int, solvedCandidate int) (Solver, bool, bool) {
+	if !strategyReady(s) {
+		return *s, false, false
+	}
 	updated := false
 	updatedPrev := false
 
-	// update candidates in rows
-	for c, cRow := range s.Candidates[row] {
-		for i, candidate := range cRow {
-			if candidate > solvedCandidate {
-				break
-			}
-			if solvedCandidate == candidate {
-				s.Candidates[row][c] = append(s.Candidates[row][c][:i], s.Candidates[row][c][i+1:]...)
-				updated = true
-				updatedPrev = updatedPrev || (c < col)
-			}
-		}
-	}
-
-	// update candidates in cols
-	for r := 0; r < s.Length; r++ {
-		for i, candidate := range s.Candidates[r][col] {
-			if candidate > solvedCandidate {
-				break
-			}
-			if solvedCandidate == candidate {
-				s.Candidates[r][col] = append(s.Candidates[r][col][:i], s.Candidates[r][col][i+1:]...)
-				updated = true
-				updatedPrev = updatedPrev || (r < row)
-			}
-		}
-	}
-
-	// update candidates in block
-	dim := s.Dim
-
-	blockRowStart := (row / dim) * dim
-	blockRowEnd := blockRowStart + dim
-	blockColStart := (col / dim) * dim
-	blockColEnd := blockColStart + dim
-
-	for r := blockRowStart; r < blockRowEnd; r++ {
-		if r == row {
-			continue
-		}
-		for c := blockColStart; c < blockColEnd; c++ {
-			if c == col {
+	l := solverLayout(s)
+	for _, house := range l.housesOf(row, col) { // update candidates in row, col and block
+		for _, cell := range house.Cells {
+			r, c := cell.Row, cell.Col
+			if (house.Kind == HouseBlock) && ((r == row) || (c == col)) {
 				continue
 			}
-			for i, candidate := range s.Candidates[r][c] {
-				if candidate > solvedCandidate {
-					break
-				}
-				if solvedCandidate == candidate {
-					s.Candidates[r][c] = append(s.Candidates[r][c][:i], s.Candidates[r][c][i+1:]...)
-					updated = true
-					updatedPrev = updatedPrev || (r < row) || (c < col)
-				}
+			if !s.eliminate(r, c, NewCandidateSet(solvedCandidate)) {
+				continue
 			}
+			updated = true
+			updatedPrev = updatedPrev || (r < row) || (c < col)
 		}
 	}
 
 	return *s, updated, updatedPrev
 }
 
-// Naked Single
-func SolveNakedSingle(s *Solver) (bool, bool) {
-	updated := false
+// strategyReady reports whether a strategy can run on the solver, it is not
+// validated (ErrNotValidated) or its missing candidates are not solvable
+func strategyReady(s *Solver) bool {
+	if !s.validated {
+		return false
+	}
+	if len(s.Candidates) != s.Length {
+		return UpdateAllCandidates(s) == nil
+	}
+	return true
+}
 
-	var cUpdatedPrev bool
+// Naked Single, the strategies change nothing and return false, false on a
+// solver not validated by CheckSudoku
+func SolveNakedSingle(s *Solver) (bool, bool) {
+	if !strategyReady(s) {
+		return false, false
+	}
+	updated := false // a value was placed
 	foundEmpty := false
 
 	for r := 0; r < s.Length; r++ {
@@ -222,11 +176,10 @@ func SolveNakedSingle(s *Solver) (bool, bool) {
 				continue
 			}
 
-			if len(s.Candidates[r][c]) == 1 {
-				s.Problem.Sudoku[r][c] = s.Candidates[r][c][0]
-				s.Candidates[r][c] = nil //[]int{}
-				*s, _, cUpdatedPrev = UpdateCandidates(s, r, c, s.Problem.Sudoku[r][c])
-				updated = updated || cUpdatedPrev
+			if s.Candidates[r][c].Count() == 1 {
+				s.place(r, c, s.Candidates[r][c].Min())
+				*s, _, _ = UpdateCandidates(s, r, c, s.Problem.Sudoku[r][c])
+				updated = true
 			} else {
 				foundEmpty = true
 			}
@@ -236,375 +189,196 @@ func SolveNakedSingle(s *Solver) (bool, bool) {
 	return updated, !foundEmpty
 }
 
+// othersCandidates returns the union of the candidates of the house without the cell
+func othersCandidates(s *Solver, house *House, row int, col int) CandidateSet {
+	var others CandidateSet
+	for _, cell := range house.Cells {
+		if (cell.Row != row) || (cell.Col != col) {
+			others = others.Union(s.Candidates[cell.Row][cell.Col])
+		}
+	}
+	return others
+}
+
 // Hidden Single
 func SolveHiddenSingle(s *Solver) (bool, bool) {
-	updated := false
-
-	var cUpdatedPrev bool
+	if !strategyReady(s) {
+		return false, false
+	}
+	updated := false // a value was placed
 	foundEmpty := false
 
+	l := solverLayout(s)
 	for r := 0; r < s.Length; r++ {
 		for c := 0; c < s.Length; c++ {
 			if s.Problem.Sudoku[r][c] != 0 {
 				continue
 			}
 
-			for i, candidate := range s.Candidates[r][c] {
-				if !IsInRowC(&s.Candidates, r, c, candidate) && !IsInColC(&s.Candidates, r, c, candidate) && !IsInBlockC(&s.Candidates, s.Dim, r, c, candidate) {
-					s.Problem.Sudoku[r][c] = candidate
-					s.Candidates[r][c] = append(s.Candidates[r][c][:i], s.Candidates[r][c][i+1:]...)
-					*s, _, cUpdatedPrev = UpdateCandidates(s, r, c, s.Problem.Sudoku[r][c])
-					updated = updated || cUpdatedPrev
-				} else {
-					foundEmpty = true
-				}
+			hidden := s.Candidates[r][c].
+				Minus(othersCandidates(s, l.row(r), r, c)).
+				Minus(othersCandidates(s, l.col(c), r, c)).
+				Minus(othersCandidates(s, l.block(r, c), r, c))
+			if hidden == 0 {
+				foundEmpty = true
+				continue
 			}
+			s.place(r, c, hidden.Min())
+			*s, _, _ = UpdateCandidates(s, r, c, s.Problem.Sudoku[r][c])
+			updated = true
 		}
 	}
 
 	return updated, !foundEmpty
 }
 
-func findForwardPairInRow(m *[][][]int, dim int, row int, col int) (int, bool) {
-	pair := (*m)[row][col]
-	for c, cCol := range (*m)[row] {
-		if c <= col {
-			continue
-		}
-		if len(cCol) == 2 {
-			pairFound := true
-			for i := 0; i < 2; i++ {
-				if cCol[i] != pair[i] {
-					pairFound = false
-					break
-				}
-			}
-			if pairFound {
-				return c, isCellsInSameBlock(dim, row, col, row, c)
-			}
-		}
-	}
-	return -1, false
+// Naked Pair in rows, cols and blocks, see SolveNakedSubset
+func SolveNakedPair(s *Solver) (bool, bool) {
+	_, updated, solved := SolveNakedSubset(s, 2)
+	return updated, solved
 }
 
-func findForwardPairInCol(m *[][][]int, dim int, row int, col int) (int, bool) {
-	pair := (*m)[row][col]
-	for r, cRow := range *m {
-		if r <= row {
+// findCandidateOnlyInBlockRow returns whether the candidate of the block is
+// only in the row of the cell and at least in one more cell
+func findCandidateOnlyInBlockRow(m [][]CandidateSet, l *layout, row int, col int, search int) bool {
+	foundValid := false
+	for _, cell := range l.block(row, col).Cells {
+		r, c := cell.Row, cell.Col
+		if ((r == row) && (c == col)) || !m[r][c].Has(search) {
 			continue
 		}
-		if len(cRow[col]) == 2 {
-			pairFound := true
-			for i := 0; i < 2; i++ {
-				if cRow[col][i] != pair[i] {
-					pairFound = false
-					break
-				}
-			}
-			if pairFound {
-				return r, isCellsInSameBlock(dim, row, col, r, col)
-			}
+		if r != row {
+			return false
 		}
+		foundValid = true
 	}
-	return -1, false
-}
-
-func isCellsInSameBlock(dim int, row1 int, col1 int, row2 int, col2 int) bool {
-	blockRowStart1 := (row1 / dim) * dim
-	blockColStart1 := (col1 / dim) * dim
-
-	blockRowStart2 := (row2 / dim) * dim
-	blockColStart2 := (col2 / dim) * dim
-
-	return (blockRowStart1 == blockRowStart2) && (blockColStart1 == blockColStart2)
+	return foundValid
 }
 
-func removeCandidatesInRow(m *[][][]int, row int, exceptCol1 int, exceptCol2 int, pair *[]int) bool {
-	updated := false
-	for cd, candidates := range (*m)[row] {
-		if (cd == exceptCol1) || (cd == exceptCol2) {
+// findCandidateOnlyInBlockCol is findCandidateOnlyInBlockRow for the col of the cell
+func findCandidateOnlyInBlockCol(m [][]CandidateSet, l *layout, row int, col int, search int) bool {
+	foundValid := false
+	for _, cell := range l.block(row, col).Cells {
+		r, c := cell.Row, cell.Col
+		if ((r == row) && (c == col)) || !m[r][c].Has(search) {
 			continue
 		}
-		for i := 0; i < len(candidates); i++ {
-			candidate := candidates[i]
-			if candidate > (*pair)[1] {
-				break
-			}
-			if (candidate == (*pair)[0]) || (candidate == (*pair)[1]) {
-				candidates = append(candidates[:i], candidates[i+1:]...)
-				(*m)[row][cd] = candidates
-				updated = updated || (cd < exceptCol1)
-				i--
-			}
+		if c != col {
+			return false
 		}
+		foundValid = true
 	}
-	return updated
+	return foundValid
 }
 
-func removeCandidatesInCol(m *[][][]int, col int, exceptRow1 int, exceptRow2 int, pair *[]int) bool {
+// removeCandidateOutsideBlock removes the candidate from the cells of the row
+// or col outside the block of the cell
+func removeCandidateOutsideBlock(s *Solver, l *layout, house *House, row int, col int, search int) bool {
 	updated := false
-	for rd, cRow := range *m {
-		if (rd == exceptRow1) || (rd == exceptRow2) {
+
+	block := l.blockIndex(row, col)
+	for _, cell := range house.Cells {
+		r, c := cell.Row, cell.Col
+		if l.blockIndex(r, c) == block { // ignore the current block
 			continue
 		}
-		candidates := cRow[col]
-		for i := 0; i < len(candidates); i++ {
-			candidate := candidates[i]
-			if candidate > (*pair)[1] {
-				break
-			}
-			if (candidate == (*pair)[0]) || (candidate == (*pair)[1]) {
-				candidates = append(candidates[:i], candidates[i+1:]...)
-				(*m)[rd][col] = candidates
-				updated = updated || (rd < exceptRow1)
-				i--
-			}
+		if s.eliminate(r, c, NewCandidateSet(search)) {
+			updated = true
 		}
 	}
 	return updated
 }
 
-func removeCandidatesInBlock(m *[][][]int, dim int, row1 int, col1 int, row2 int, col2 int, pair *[]int) bool {
-	updated := false
-
-	blockRowStart := (row1 / dim) * dim
-	blockRowEnd := blockRowStart + dim
-	blockColStart := (col1 / dim) * dim
-	blockColEnd := blockColStart + dim
-
-	for r := blockRowStart; r < blockRowEnd; r++ {
-		for c := blockColStart; c < blockColEnd; c++ {
-			if ((r == row1) && (c <= col1)) || ((r == row2) && (c <= col2)) {
-				continue
-			}
-			candidates := (*m)[r][c]
-			for i := 0; i < len(candidates); i++ {
-				candidate := candidates[i]
-				if candidate > (*pair)[1] {
-					break
-				}
-				if (candidate == (*pair)[0]) || (candidate == (*pair)[1]) {
-					candidates = append(candidates[:i], candidates[i+1:]...)
-					(*m)[r][c] = candidates
-					updated = updated || (r < row1) || ((r == row1) && (c < col1))
-					i--
-				}
-			}
-		}
+// Point Pair (Triple)
+func SolvePointingPair(s *Solver) (bool, bool) {
+	if !strategyReady(s) {
+		return false, false
 	}
-	return updated
-}
-
-// Naked Pair
-func SolveNakedPair(s *Solver) (bool, bool) {
 	updated := false
 	foundEmpty := false
 
+	l := solverLayout(s)
 	for r := 0; r < (*s).Length; r++ {
 		for c := 0; c < (*s).Length; c++ {
 			if s.Problem.Sudoku[r][c] != 0 {
 				continue
 			}
-
-			if len(s.Candidates[r][c]) == 2 {
-
-				row := r
-				col := c
-				block := false
-				col, block = findForwardPairInRow(&s.Candidates, s.Dim, r, c)
-				if col == -1 {
-					row, block = findForwardPairInCol(&s.Candidates, s.Dim, r, c)
-					col = c
+			foundEmpty = true // pointing pairs never place a value
+			for _, candidate := range s.Candidates[r][c].Values() {
+				if findCandidateOnlyInBlockRow(s.Candidates, l, r, c, candidate) {
+					updated = removeCandidateOutsideBlock(s, l, l.row(r), r, c, candidate) || updated
+				} else if findCandidateOnlyInBlockCol(s.Candidates, l, r, c, candidate) {
+					updated = removeCandidateOutsideBlock(s, l, l.col(c), r, c, candidate) || updated
 				}
-				if (col != -1) && (row != -1) {
-
-					updatedPrevInBlock := false
-					// remove from rows
-					if block {
-						updatedPrevInBlock = removeCandidatesInBlock(&s.Candidates, s.Dim, r, c, row, col, &s.Candidates[r][c])
-					}
-					updatedPrev := false
-					if r == row {
-						updatedPrev = removeCandidatesInRow(&s.Candidates, r, c, col, &s.Candidates[r][c])
-					} else if c == col {
-						updatedPrev = removeCandidatesInCol(&s.Candidates, c, r, row, &s.Candidates[r][c])
-					}
-
-					updated = updated || updatedPrevInBlock || updatedPrev
-				}
-			} else {
-				foundEmpty = true
 			}
 		}
 	}
-
 	return updated, !foundEmpty
 }
 
-func findCandidateOnlyInBlockRow(m *[][][]int, dim int, row int, col int, search int) bool {
-	foundValid := false
-	foundInvalid := false
-
-	blockRowStart := (row / dim) * dim
-	blockRowEnd := blockRowStart + dim
-	blockColStart := (col / dim) * dim
-	blockColEnd := blockColStart + dim
-
-	for r := blockRowStart; r < blockRowEnd; r++ {
-		for c := blockColStart; c < blockColEnd; c++ {
-			if (r == row) && (c == col) {
-				continue
-			}
-			if foundValid && (r == row) {
-				continue
-			}
-			candidates := (*m)[r][c]
-			for i := 0; i < len(candidates); i++ {
-				candidate := candidates[i]
-				if candidate > search {
-					break
-				}
-				if candidate == search {
-					if !foundValid {
-						foundValid = r == row
-					}
-					foundInvalid = r != row
-					if foundInvalid {
-						return false
-					}
-					break
-				}
-			}
-		}
+// SolveDepthFirstSearch solves the sudoku starting with the cell [rInit, cInit],
+// the first empty cell is returned in *UnsolvableError when there is no solution
+func SolveDepthFirstSearch(s *Solver, rInit int, cInit int, rec int) (bool, error) {
+	if !s.validated {
+		return false, ErrNotValidated
 	}
-	return foundValid
-}
-
-func findCandidateOnlyInBlockCol(m *[][][]int, dim int, row int, col int, search int) bool {
-	foundValid := false
-	foundInvalid := false
-
-	blockRowStart := (row / dim) * dim
-	blockRowEnd := blockRowStart + dim
-	blockColStart := (col / dim) * dim
-	blockColEnd := blockColStart + dim
-
-	for r := blockRowStart; r < blockRowEnd; r++ {
-		for c := blockColStart; c < blockColEnd; c++ {
-			if (r == row) && (c == col) {
-				continue
-			}
-			if foundValid && (c == col) {
-				continue
-			}
-			candidates := (*m)[r][c]
-			for i := 0; i < len(candidates); i++ {
-				candidate := candidates[i]
-				if candidate > search {
-					break
-				}
-				if candidate == search {
-					if !foundValid {
-						foundValid = c == col
-					}
-					foundInvalid = c != col
-					if foundInvalid {
-						return false
-					}
-				}
-			}
-		}
+	if solveDepthFirstSearch(s, rInit, cInit, rec) {
+		return true, nil
 	}
-	return foundValid
+	return false, unsolvableError(s, rInit, cInit)
 }
 
-func removeCandidateInRowOutsideBlock(m *[][][]int, length int, dim int, row int, col int, search int) bool {
-	updated := false
-
-	blockRowStart := (row / dim) * dim
-	blockRowEnd := blockRowStart + dim
-	blockColStart := (col / dim) * dim
-	blockColEnd := blockColStart + dim
-
-	r := row
-	for c := 0; c < length; c++ {
-		if (r >= blockRowStart) && (c >= blockColStart) && (r < blockRowEnd) && (c < blockColEnd) { // ignore the current block
-			continue
-		}
-		candidates := (*m)[r][c]
-		for i := 0; i < len(candidates); i++ {
-			candidate := candidates[i]
-			if candidate > search {
-				break
-			}
-			if candidate == search {
-				candidates = append(candidates[:i], candidates[i+1:]...)
-				(*m)[r][c] = candidates
-				updated = true
-				break
+// unsolvableError returns *UnsolvableError for the first empty cell starting
+// with the cell [rInit, cInit]
+func unsolvableError(s *Solver, rInit int, cInit int) error {
+	c := cInit
+	for r := rInit; r < s.Length; r++ {
+		for ; c < s.Length; c++ {
+			if s.Problem.Sudoku[r][c] == 0 {
+				return &UnsolvableError{Cell{Row: r, Col: c}}
 			}
 		}
+		c = 0
 	}
-	return updated
+	return ErrUnsolvable
 }
 
-func removeCandidateInColOutsideBlock(m *[][][]int, length int, dim int, row int, col int, search int) bool {
-	updated := false
-
-	blockRowStart := (row / dim) * dim
-	blockRowEnd := blockRowStart + dim
-	blockColStart := (col / dim) * dim
-	blockColEnd := blockColStart + dim
-
-	for r := 0; r < length; r++ {
-		c := col
-		if (r >= blockRowStart) && (c >= blockColStart) && (r < blockRowEnd) && (c < blockColEnd) { // ignore the current block
-			continue
-		}
-		candidates := (*m)[r][c]
-		for i := 0; i < len(candidates); i++ {
-			candidate := candidates[i]
-			if candidate > search {
-				break
-			}
-			if candidate == search {
-				candidates = append(candidates[:i], candidates[i+1:]...)
-				(*m)[r][c] = candidates
-				updated = true
-				break
-			}
-		}
-	}
-	return updated
+func solveDepthFirstSearch(s *Solver, rInit int, cInit int, rec int) bool {
+	// stop at the first solution, it stays in the solver
+	return !depthFirstSearch(s, rInit, cInit, rec, nil, func() bool { return false })
 }
 
-// Point Pair (Triple)
-func SolvePointingPair(s *Solver) (bool, bool) {
-	updated := false
-	foundEmpty := false
-
-	for r := 0; r < (*s).Length; r++ {
-		for c := 0; c < (*s).Length; c++ {
-			if s.Problem.Sudoku[r][c] != 0 {
-				continue
-			}
-			for _, candidate := range s.Candidates[r][c] {
-				if findCandidateOnlyInBlockRow(&s.Candidates, s.Dim, r, c, candidate) {
-					removeCandidateInRowOutsideBlock(&s.Candidates, s.Length, s.Dim, r, c, candidate)
-				} else if findCandidateOnlyInBlockCol(&s.Candidates, s.Dim, r, c, candidate) {
-					removeCandidateInColOutsideBlock(&s.Candidates, s.Length, s.Dim, r, c, candidate)
-				} else {
-					foundEmpty = true
-				}
-			}
-		}
+// searchDepthFirst solves the sudoku with Depth First Search under the control,
+// the guessed values are removed when the search is interrupted
+func searchDepthFirst(s *Solver, ctl *control) (bool, error) {
+	saved := copyMatrix(&s.Problem)
+	stopped := false
+	applyStrategy(s, "DepthFirstSearch", func(s *Solver) (bool, bool) {
+		stopped = !depthFirstSearch(s, 0, 0, 1, ctl, func() bool { return false })
+		solved := stopped && (ctl.err == nil)
+		return solved, solved
+	})
+	if ctl.err != nil {
+		for r, row := range saved.Sudoku {
+			copy(s.Problem.Sudoku[r], row)
+		}
+		return false, ctl.error(s)
 	}
-	return updated, !foundEmpty
+	if stopped {
+		return true, nil
+	}
+	return false, unsolvableError(s, 0, 0)
 }
 
-func SolveDepthFirstSearch(s *Solver, rInit int, cInit int, rec int) bool {
-	//fmt.Printf("rec:%v\n", rec)
-	solved := false
+// depthFirstSearch calls visit with every solution starting with the cell
+// [rInit, cInit], the solution is in the solver during the call. The search
+// stops when visit returns false or the control interrupts it and the values
+// stay in the solver, otherwise the empty cells are restored. It returns false
+// when stopped.
+func depthFirstSearch(s *Solver, rInit int, cInit int, rec int, ctl *control, visit func() bool) bool {
+	if !ctl.node(rec) {
+		return false
+	}
 	emptyFound := false
 	row := -1
 	col := -1
@@ -626,75 +400,116 @@ func SolveDepthFirstSearch(s *Solver, rInit int, cInit int, rec int) bool {
 	}
 
 	if !emptyFound { // sudoku is solved
-		solved = true
-	} else {
-
-		candidates := getRowColCandidates(s, row, col)
-
-		for i := 0; i < len(*candidates); i++ {
-			s.Problem.Sudoku[row][col] = (*candidates)[i]
-			valid := true
-			//valid := CheckValue(s, row, col)
-			if valid {
-				solved = SolveDepthFirstSearch(s, row, col+1, rec+1)
-				if solved {
-					break
-				}
-			}
-		}
+		return visit()
+	}
 
-		if !solved {
-			s.Problem.Sudoku[row][col] = 0
+	candidates := rowColCandidates(s, row, col)
+	for candidate := candidates.Min(); candidate != 0; candidate = candidates.Next(candidate) {
+		s.Problem.Sudoku[row][col] = candidate
+		s.notify(Event{Kind: EventGuess, Cell: Cell{Row: row, Col: col}, Value: candidate, Depth: rec})
+		if !depthFirstSearch(s, row, col+1, rec+1, ctl, visit) {
+			return false
 		}
 	}
-	return solved
+	s.Problem.Sudoku[row][col] = 0
+	s.notify(Event{Kind: EventBacktrack, Cell: Cell{Row: row, Col: col}, Depth: rec})
+	return true
 }
 
-func Solve(s *Solver) bool {
+// Backend is the algorithm used by SolveWithOptions
+type Backend int
 
-	UpdateAllCandidates(s)
+const (
+	BackendStrategies       Backend = iota // strategies with Depth First Search as the final resort
+	BackendDLX                             // Dancing Links exact cover search
+	BackendDepthFirstSearch                // Depth First Search only
+)
 
-	fmt.Println("solving with NakedSingle")
-	updated, solved := SolveNakedSingle(s)
+type SolveOptions struct {
+	Backend  Backend
+	Budget   Budget
+	Observer Observer // receives the events of the solver, nil for none
 
-	var cUpdated bool
-	exit := solved
+	// strategies of BackendStrategies in the order of application, nil for
+	// DefaultPipeline
+	Pipeline []Strategy
+	NoSearch bool // BackendStrategies does not fall back to Depth First Search
 
-	for !exit {
-		cUpdated = updated
-		updated = false
-		for cUpdated && !solved {
-			cUpdated, solved = SolveNakedSingle(s)
-			fmt.Println("again solving with NakedSingle")
-		}
+	// BackendStrategies starts with Solver.Candidates as loaded by
+	// ReadPencilMarks, ParseHodoku, ParseS9B or UnmarshalJSON instead of
+	// recalculating them, e.g. to reproduce a mid-solve position
+	KeepCandidates bool
+}
 
-		if !solved {
-			fmt.Println("solving with HiddenSingle")
-			cUpdated, solved = SolveHiddenSingle(s)
-			updated = updated || cUpdated
-		}
+// Solve solves the sudoku with the strategies and Depth First Search
+func Solve(s *Solver) (bool, error) {
+	return SolveWithOptions(s, SolveOptions{})
+}
 
-		if !solved {
-			fmt.Println("solving with NakedPair")
-			cUpdated, solved = SolveNakedPair(s)
-			updated = updated || cUpdated
+func SolveWithOptions(s *Solver, o SolveOptions) (bool, error) {
+	return SolveContext(context.Background(), s, o)
+}
+
+// SolveContext solves the sudoku until the context is done or the budget of
+// the options is exhausted, then *InterruptedError with the statistics is
+// returned. The values placed by the strategies are kept, guesses of the
+// search are removed.
+func SolveContext(ctx context.Context, s *Solver, o SolveOptions) (bool, error) {
+	ctl := newControl(ctx, o.Budget)
+	s.observer = o.Observer
+	defer func() { s.observer = nil }()
+	switch o.Backend {
+	case BackendStrategies:
+		pipeline := o.Pipeline
+		if pipeline == nil {
+			pipeline = DefaultPipeline()
+		}
+		return solveStrategies(s, ctl, pipeline, o.NoSearch, o.KeepCandidates)
+	case BackendDLX:
+		return solveDLX(s, ctl)
+	case BackendDepthFirstSearch:
+		if err := UpdateAllCandidates(s); err != nil {
+			return false, err
+		}
+		return searchDepthFirst(s, ctl)
+	}
+	return false, fmt.Errorf("ERROR: Unknown solver backend %v", o.Backend)
+}
+
+// solveStrategies applies the pipeline in rounds until the sudoku is solved or
+// a round changes nothing, then Depth First Search is used unless noSearch.
+// The candidates are recalculated unless keepCandidates and they are set.
+func solveStrategies(s *Solver, ctl *control, pipeline []Strategy, noSearch bool, keepCandidates bool) (bool, error) {
+	if !s.validated {
+		return false, ErrNotValidated
+	}
+	if !keepCandidates || (len(s.Candidates) != s.Length) {
+		if err := UpdateAllCandidates(s); err != nil {
+			return false, err
 		}
+	}
 
-		if !solved {
-			fmt.Println("solving with PointingPair")
-			cUpdated, solved = SolvePointingPair(s)
+	solved := false
+	for updated := true; updated && !solved; {
+		if !ctl.iteration() {
+			return false, ctl.error(s)
+		}
+		updated = false
+		for _, st := range pipeline {
+			cUpdated, cSolved := applyStrategy(s, st.Name(), func(s *Solver) (bool, bool) {
+				result := st.Apply(s)
+				return result.Updated, result.Solved
+			})
 			updated = updated || cUpdated
+			if cSolved {
+				solved = true
+				break
+			}
 		}
-
-		exit = !updated || solved
 	}
 
-	fmt.Println("after strategies solved:")
-	Print(s)
-
-	if !solved {
-		fmt.Println("not solved so far - using DepthFirstSearch")
-		solved = SolveDepthFirstSearch(s, 0, 0, 1)
+	if !solved && !noSearch {
+		return searchDepthFirst(s, ctl)
 	}
-	return solved
+	return solved, nil
 }
@@ -1,6 +1,7 @@
 package solver
 
 import (
+	"errors"
 	"fmt"
 	"testing"
 	"time"
@@ -32,8 +33,11 @@ func TestSudokuSolver1(t *testing.T) {
 	Print(v)
 
 	start := time.Now()
-	solved := Solve(v)
+	solved, err := Solve(v)
 	duration := time.Since(start)
+	if err != nil {
+		t.Errorf("error: %v\n", err)
+	}
 
 	fmt.Printf("total %s (%d)\n", duration, duration.Nanoseconds())
 
@@ -76,8 +80,11 @@ func TestSudokuSolver2(t *testing.T) {
 	Print(v)
 
 	start := time.Now()
-	solved := Solve(v)
+	solved, err := Solve(v)
 	duration := time.Since(start)
+	if err != nil {
+		t.Errorf("error: %v\n", err)
+	}
 
 	fmt.Printf("total %s (%d)\n", duration, duration.Nanoseconds())
 
@@ -120,8 +127,11 @@ func TestSudokuSolver3(t *testing.T) {
 	Print(v)
 
 	start := time.Now()
-	solved := Solve(v)
+	solved, err := Solve(v)
 	duration := time.Since(start)
+	if !errors.Is(err, ErrUnsolvable) {
+		t.Errorf("expected %v, got %v", ErrUnsolvable, err)
+	}
 
 	fmt.Printf("total %s (%d)\n", duration, duration.Nanoseconds())
 
@@ -157,19 +167,12 @@ func TestSudokuSolver4(t *testing.T) {
 	fmt.Println("sudoku to solve:")
 	Print(v)
 
-	func() {
-		defer func() {
-			if err := recover(); err != nil {
-				fmt.Printf("\nOK: panic occurred: %v\n", err)
-			}
-		}()
-
-		Solve(v)
-
-		errorStr := "should raise panic"
-		fmt.Printf("\n" + errorStr + "\n")
-		t.Errorf(errorStr)
-	}()
+	solved, err := Solve(v)
+	var unsolvable *UnsolvableError
+	if solved || !errors.As(err, &unsolvable) || !errors.Is(err, ErrUnsolvable) {
+		t.Fatalf("expected %v, got %v", ErrUnsolvable, err)
+	}
+	fmt.Printf("\nOK: %v\n", err)
 }
 
 func TestSudokuSolver5(t *testing.T) {
@@ -190,8 +193,11 @@ func TestSudokuSolver5(t *testing.T) {
 	Print(v)
 
 	start := time.Now()
-	solved := Solve(v)
+	solved, err := Solve(v)
 	duration := time.Since(start)
+	if err != nil {
+		t.Errorf("error: %v\n", err)
+	}
 
 	fmt.Printf("total %s (%d)\n", duration, duration.Nanoseconds())
 
@@ -207,3 +213,61 @@ func TestSudokuSolver5(t *testing.T) {
 		t.Errorf("Sudoku not solved")
 	}
 }
+
+func TestSudokuSolver6(t *testing.T) {
+	tests := []string{
+		"1..4....6..3.3..6.5..2....2..56..3..",                             // 6x6 with 2x3 blocks
+		"1.......3...5.....6.......2......7.......1......8.......4.......", // 8x8 with 2x4 blocks
+		"1...........4...........7...........A...........2...........5...........8...........B...........3...........6...........9...........C...........", // 12x12 with 3x4 blocks
+	}
+	for i, test := range tests {
+		m, err := ParseLine(test)
+		if err != nil {
+			t.Fatalf("test %v: error: %v\n", i, err)
+		}
+		v, err := CheckSudoku(m)
+		if err != nil {
+			t.Fatalf("test %v: error: %v\n", i, err)
+		}
+		solved, err := Solve(v)
+		if err != nil || !solved {
+			t.Fatalf("test %v: sudoku not solved: %v", i, err)
+		}
+		if err = Validate(&v.Problem); err != nil {
+			t.Errorf("test %v: sudoku not valid: %v", i, err)
+		}
+		for _, row := range v.Problem.Sudoku {
+			for _, value := range row {
+				if value == 0 {
+					t.Errorf("test %v: sudoku not solved", i)
+				}
+			}
+		}
+	}
+}
+
+func TestSudokuSolver7(t *testing.T) {
+	m, _ := ParseLine("1..4....6..3.3..6.5..2....2..56..3..")
+	s, _ := CheckSudoku(m)
+	UpdateAllCandidates(s)
+	candidates := s.CandidateSlices()
+
+	// [0, 1] and [0, 2] share the 2x3 block
+	if !IsInBlockBoxesC(&candidates, 2, 3, 0, 1, candidates[0][2][0]) {
+		t.Errorf("candidate %v of [0, 2] not found in the block", candidates[0][2][0])
+	}
+	if IsInBlockBoxesC(&candidates, 2, 3, 0, 1, 1) {
+		t.Errorf("placed value found as candidate")
+	}
+
+	m, _ = ParseLine("1.....2..3.....4")
+	s, _ = CheckSudoku(m)
+	UpdateAllCandidates(s)
+	candidates = s.CandidateSlices()
+	if IsInBlockC(&candidates, 2, 0, 1, 3) != IsInBlockBoxesC(&candidates, 2, 2, 0, 1, 3) {
+		t.Errorf("square and box blocks differ")
+	}
+	if !IsInBlockC(&candidates, 2, 0, 1, 3) {
+		t.Errorf("candidate 3 of [1, 0] not found in the block")
+	}
+}
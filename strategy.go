@@ -0,0 +1,128 @@
+package solver
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// StepResult is the result of applying a strategy once
+type StepResult struct {
+	Updated bool // values or candidates were changed
+	Solved  bool // no empty cell is left
+}
+
+// Strategy is a solving technique of the pipeline. Apply works on the values
+// and Solver.Candidates, the candidates are up to date before the first step.
+type Strategy interface {
+	Name() string
+	Difficulty() int // weight of the technique, harder techniques are heavier
+	Apply(s *Solver) StepResult
+}
+
+type funcStrategy struct {
+	name       string
+	difficulty int
+	apply      func(s *Solver) (bool, bool)
+}
+
+// NewStrategy returns the strategy of a function with the (updated, solved)
+// result of SolveNakedSingle and the other strategy functions
+func NewStrategy(name string, difficulty int, apply func(s *Solver) (bool, bool)) Strategy {
+	return &funcStrategy{name: name, difficulty: difficulty, apply: apply}
+}
+
+func (f *funcStrategy) Name() string {
+	return f.name
+}
+
+func (f *funcStrategy) Difficulty() int {
+	return f.difficulty
+}
+
+func (f *funcStrategy) Apply(s *Solver) StepResult {
+	updated, solved := f.apply(s)
+	return StepResult{Updated: updated, Solved: solved}
+}
+
+var (
+	NakedSingle     = NewStrategy("NakedSingle", 10, SolveNakedSingle)
+	HiddenSingle    = NewStrategy("HiddenSingle", 15, SolveHiddenSingle)
+	PointingPair    = NewStrategy("PointingPair", 30, SolvePointingPair)
+	NakedPair       = NewStrategy("NakedPair", 35, SolveNakedPair)
+	HiddenPair      = NewStrategy("HiddenPair", 40, SolveHiddenPair)
+	NakedTriple     = NewStrategy("NakedTriple", 45, SolveNakedTriple)
+	HiddenTriple    = NewStrategy("HiddenTriple", 50, SolveHiddenTriple)
+	NakedQuad       = NewStrategy("NakedQuad", 55, SolveNakedQuad)
+	HiddenQuad      = NewStrategy("HiddenQuad", 60, SolveHiddenQuad)
+	AllNakedSubsets = NewStrategy("AllNakedSubsets", 70, SolveNakedSubsets)
+)
+
+var registry = struct {
+	sync.RWMutex
+	strategies map[string]Strategy
+}{strategies: make(map[string]Strategy)}
+
+func init() {
+	for _, st := range []Strategy{NakedSingle, HiddenSingle, PointingPair, NakedPair, HiddenPair, HiddenTriple, HiddenQuad, NakedTriple, NakedQuad, AllNakedSubsets} {
+		registry.strategies[st.Name()] = st
+	}
+}
+
+// RegisterStrategy adds the strategy to the registry, names are unique
+func RegisterStrategy(st Strategy) error {
+	if st.Name() == "" {
+		return fmt.Errorf("ERROR: Strategy without name")
+	}
+	registry.Lock()
+	defer registry.Unlock()
+	if _, ok := registry.strategies[st.Name()]; ok {
+		return fmt.Errorf("ERROR: Strategy %v is already registered", st.Name())
+	}
+	registry.strategies[st.Name()] = st
+	return nil
+}
+
+// LookupStrategy returns the registered strategy with the name
+func LookupStrategy(name string) (Strategy, bool) {
+	registry.RLock()
+	defer registry.RUnlock()
+	st, ok := registry.strategies[name]
+	return st, ok
+}
+
+// Strategies returns the registered strategies ordered by difficulty and name
+func Strategies() []Strategy {
+	registry.RLock()
+	strategies := make([]Strategy, 0, len(registry.strategies))
+	for _, st := range registry.strategies {
+		strategies = append(strategies, st)
+	}
+	registry.RUnlock()
+
+	sort.Slice(strategies, func(i, j int) bool {
+		if strategies[i].Difficulty() != strategies[j].Difficulty() {
+			return strategies[i].Difficulty() < strategies[j].Difficulty()
+		}
+		return strategies[i].Name() < strategies[j].Name()
+	})
+	return strategies
+}
+
+// Pipeline returns the registered strategies with the names in the given order
+func Pipeline(names ...string) ([]Strategy, error) {
+	pipeline := make([]Strategy, 0, len(names))
+	for _, name := range names {
+		st, ok := LookupStrategy(name)
+		if !ok {
+			return nil, fmt.Errorf("ERROR: Unknown strategy %v", name)
+		}
+		pipeline = append(pipeline, st)
+	}
+	return pipeline, nil
+}
+
+// DefaultPipeline returns the strategies used by Solve
+func DefaultPipeline() []Strategy {
+	return []Strategy{NakedSingle, HiddenSingle, NakedPair, PointingPair, HiddenPair, NakedTriple, HiddenTriple, NakedQuad, HiddenQuad}
+}
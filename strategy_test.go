@@ -0,0 +1,132 @@
+package solver
+
+import (
+	"testing"
+)
+
+const easySudoku = "..3.2.6..9..3.5..1..18.64....81.29..7.......8..67.82....26.95..8..2.3..9..5.1.3.."
+
+func TestStrategy1(t *testing.T) {
+	if err := RegisterStrategy(NakedSingle); err == nil {
+		t.Errorf("duplicate strategy registered")
+	}
+	if _, err := Pipeline("NakedSingle", "Unknown"); err == nil {
+		t.Errorf("unknown strategy in pipeline")
+	}
+
+	pipeline, err := Pipeline("HiddenSingle", "NakedSingle")
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	if (pipeline[0] != HiddenSingle) || (pipeline[1] != NakedSingle) {
+		t.Errorf("unexpected pipeline %v", pipeline)
+	}
+
+	strategies := Strategies()
+	for i := 1; i < len(strategies); i++ {
+		if strategies[i-1].Difficulty() > strategies[i].Difficulty() {
+			t.Errorf("strategies not ordered by difficulty: %v before %v", strategies[i-1].Name(), strategies[i].Name())
+		}
+	}
+}
+
+func TestStrategy2(t *testing.T) {
+	// custom strategy counting the rounds
+	rounds := 0
+	counter := NewStrategy("Counter", 0, func(s *Solver) (bool, bool) {
+		rounds++
+		return false, false
+	})
+	if err := RegisterStrategy(counter); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	t.Cleanup(func() {
+		registry.Lock()
+		delete(registry.strategies, "Counter")
+		registry.Unlock()
+	})
+	pipeline, _ := Pipeline("Counter", "NakedSingle")
+
+	guesses := 0
+	m, _ := ParseLine(easySudoku)
+	s, _ := CheckSudoku(m)
+	solved, err := SolveWithOptions(s, SolveOptions{Pipeline: pipeline, Observer: ObserverFunc(func(e Event) {
+		if e.Kind == EventGuess {
+			guesses++
+		}
+	})})
+	if err != nil || !solved {
+		t.Fatalf("sudoku not solved: %v", err)
+	}
+	if rounds == 0 {
+		t.Errorf("custom strategy not applied")
+	}
+	if guesses != 0 {
+		t.Errorf("unexpected guesses: %v", guesses)
+	}
+	if err = Validate(&s.Problem); err != nil {
+		t.Errorf("sudoku not valid: %v", err)
+	}
+}
+
+func TestStrategy3(t *testing.T) {
+	// without the search the hard sudoku stays unsolved
+	m, _ := ParseLine(hardSudoku)
+	s, _ := CheckSudoku(m)
+	solved, err := SolveWithOptions(s, SolveOptions{NoSearch: true})
+	if err != nil || solved {
+		t.Fatalf("expected unsolved sudoku, got %v, %v", solved, err)
+	}
+	if len(s.Grid().EmptyCells()) == 0 {
+		t.Errorf("no empty cells left")
+	}
+
+	// an empty pipeline uses only the search
+	m, _ = ParseLine(hardSudoku)
+	s, _ = CheckSudoku(m)
+	solved, err = SolveWithOptions(s, SolveOptions{Pipeline: []Strategy{}})
+	if err != nil || !solved {
+		t.Fatalf("sudoku not solved: %v", err)
+	}
+}
+
+func TestStrategy4(t *testing.T) {
+	// 1 of block 0 only in row 0, so it is removed from row 0 outside the block
+	s := emptySolver()
+	for r := 1; r < 3; r++ {
+		for c := 0; c < 3; c++ {
+			s.Candidates[r][c] = s.Candidates[r][c].Remove(1)
+		}
+	}
+
+	if result := PointingPair.Apply(s); !result.Updated || result.Solved {
+		t.Errorf("unexpected result %+v", result)
+	}
+	for c := 3; c < 9; c++ {
+		if s.Candidates[0][c].Has(1) {
+			t.Errorf("[0, %v]: unexpected candidates %v", c, s.Candidates[0][c])
+		}
+	}
+	if result := PointingPair.Apply(s); result.Updated {
+		t.Errorf("pointing pair applied twice")
+	}
+}
+
+func TestStrategy5(t *testing.T) {
+	// a placed value is an update of every strategy
+	for i, st := range []Strategy{NakedSingle, HiddenSingle} {
+		m := SudokuMatrix{Sudoku: [][]int{{0, 2, 0, 4}, {0, 4, 0, 2}, {2, 1, 4, 3}, {4, 3, 2, 0}}}
+		s, err := CheckSudoku(&m)
+		if err != nil {
+			t.Fatalf("test %v: error: %v", i, err)
+		}
+		UpdateAllCandidates(s)
+		result := st.Apply(s)
+		if !result.Updated {
+			t.Errorf("test %v: %v placed values without update", i, st.Name())
+		}
+		if s.Problem.Sudoku[3][3] != 1 {
+			t.Errorf("test %v: %v did not place 1 in [3, 3]", i, st.Name())
+		}
+	}
+}
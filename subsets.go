@@ -0,0 +1,286 @@
+package solver
+
+import (
+	"math/bits"
+)
+
+// Subset is a naked or hidden subset of a house: the digits are confined to
+// the cells, so candidates are removed from the cells or the rest of the house
+type Subset struct {
+	Kind   HouseKind
+	Index  int
+	Cells  []Cell
+	Digits CandidateSet
+}
+
+// combinations calls visit with every k of n indexes in increasing order until
+// visit returns false
+func combinations(n int, k int, visit func(indexes []int) bool) {
+	if (k <= 0) || (k > n) {
+		return
+	}
+	indexes := make([]int, k)
+	for i := range indexes {
+		indexes[i] = i
+	}
+	for {
+		if !visit(indexes) {
+			return
+		}
+		i := k - 1
+		for (i >= 0) && (indexes[i] == n-k+i) {
+			i--
+		}
+		if i < 0 {
+			return
+		}
+		indexes[i]++
+		for j := i + 1; j < k; j++ {
+			indexes[j] = indexes[j-1] + 1
+		}
+	}
+}
+
+// hiddenSubsetsInHouse returns the hidden subsets of the size in the house with
+// other candidates in their cells
+func hiddenSubsetsInHouse(s *Solver, house *House, size int) []Subset {
+	positions := make([]uint64, s.Length+1) // cells of the house with the digit, by digit
+	empty := 0
+	for i, cell := range house.Cells {
+		if s.Problem.Sudoku[cell.Row][cell.Col] != 0 {
+			continue
+		}
+		empty++
+		for _, digit := range s.Candidates[cell.Row][cell.Col].Values() {
+			positions[digit] |= 1 << i
+		}
+	}
+	if empty <= size {
+		return nil
+	}
+
+	digits := make([]int, 0, s.Length)
+	for digit := 1; digit <= s.Length; digit++ {
+		if n := bits.OnesCount64(positions[digit]); (n > 0) && (n <= size) {
+			digits = append(digits, digit)
+		}
+	}
+
+	subsets := make([]Subset, 0)
+	combinations(len(digits), size, func(indexes []int) bool {
+		var cells uint64
+		var set CandidateSet
+		for _, i := range indexes {
+			cells |= positions[digits[i]]
+			set = set.Add(digits[i])
+		}
+		if bits.OnesCount64(cells) != size {
+			return true
+		}
+		subset := Subset{Kind: house.Kind, Index: house.Index, Digits: set}
+		others := false
+		for i, cell := range house.Cells {
+			if cells&(1<<i) != 0 {
+				subset.Cells = append(subset.Cells, cell)
+				others = others || (s.Candidates[cell.Row][cell.Col].Minus(set) != 0)
+			}
+		}
+		if others {
+			subsets = append(subsets, subset)
+		}
+		return true
+	})
+	return subsets
+}
+
+// nakedSubsetsInHouse returns the naked subsets of the size in the house with
+// their digits in other cells of the house
+func nakedSubsetsInHouse(s *Solver, house *House, size int) []Subset {
+	cells := make([]Cell, 0, len(house.Cells))
+	empty := 0
+	for _, cell := range house.Cells {
+		if s.Problem.Sudoku[cell.Row][cell.Col] != 0 {
+			continue
+		}
+		empty++
+		if n := s.Candidates[cell.Row][cell.Col].Count(); (n > 0) && (n <= size) {
+			cells = append(cells, cell)
+		}
+	}
+	if empty <= size {
+		return nil
+	}
+
+	subsets := make([]Subset, 0)
+	combinations(len(cells), size, func(indexes []int) bool {
+		var set CandidateSet
+		for _, i := range indexes {
+			set = set.Union(s.Candidates[cells[i].Row][cells[i].Col])
+		}
+		if set.Count() != size {
+			return true
+		}
+		subset := Subset{Kind: house.Kind, Index: house.Index, Digits: set}
+		for _, i := range indexes {
+			subset.Cells = append(subset.Cells, cells[i])
+		}
+		for _, cell := range house.Cells {
+			if !subset.contains(cell) && (s.Candidates[cell.Row][cell.Col].Intersect(set) != 0) {
+				subsets = append(subsets, subset)
+				break
+			}
+		}
+		return true
+	})
+	return subsets
+}
+
+func (subset *Subset) contains(cell Cell) bool {
+	for _, c := range subset.Cells {
+		if c == cell {
+			return true
+		}
+	}
+	return false
+}
+
+// NakedSubsets returns the naked subsets of the size in all houses, which
+// remove candidates. Size 2 is Naked Pair, 3 Naked Triple and 4 Naked Quad.
+func NakedSubsets(s *Solver, size int) []Subset {
+	if !strategyReady(s) {
+		return nil
+	}
+	subsets := make([]Subset, 0)
+	l := solverLayout(s)
+	for i := range l.houses {
+		subsets = append(subsets, nakedSubsetsInHouse(s, &l.houses[i], size)...)
+	}
+	return subsets
+}
+
+// SolveNakedSubset removes the digits of the naked subsets of the size from
+// the other cells of the house, house by house. Any size below the length is
+// accepted, e.g. larger subsets of 16x16 sudoku. It returns the applied subsets.
+func SolveNakedSubset(s *Solver, size int) ([]Subset, bool, bool) {
+	if !strategyReady(s) {
+		return nil, false, false
+	}
+	applied := make([]Subset, 0)
+	updated := false
+	l := solverLayout(s)
+	for i := range l.houses {
+		house := &l.houses[i]
+		for _, subset := range nakedSubsetsInHouse(s, house, size) {
+			removed := false
+			for _, cell := range house.Cells {
+				if !subset.contains(cell) {
+					removed = s.eliminate(cell.Row, cell.Col, subset.Digits) || removed
+				}
+			}
+			if removed {
+				applied = append(applied, subset)
+				updated = true
+			}
+		}
+	}
+	return applied, updated, isSolved(s)
+}
+
+// maxNakedSubsetsSize limits SolveNakedSubsets, the combinations of larger
+// sizes in 16x16 or 25x25 houses can't be interrupted by the solver budget
+const maxNakedSubsetsSize = 4
+
+// SolveNakedSubsets applies the naked subsets of the sizes 2 up to 4 and half
+// of the length, larger naked subsets are hidden subsets of the other cells.
+// Larger sizes are applied with SolveNakedSubset.
+func SolveNakedSubsets(s *Solver) (bool, bool) {
+	if !strategyReady(s) {
+		return false, false
+	}
+	updated := false
+	for size := 2; size <= min(s.Length/2, maxNakedSubsetsSize); size++ {
+		_, cUpdated, _ := SolveNakedSubset(s, size)
+		updated = updated || cUpdated
+	}
+	return updated, isSolved(s)
+}
+
+// HiddenSubsets returns the hidden subsets of the size in all houses, which
+// remove candidates. Size 2 is Hidden Pair, 3 Hidden Triple and 4 Hidden Quad.
+func HiddenSubsets(s *Solver, size int) []Subset {
+	if !strategyReady(s) {
+		return nil
+	}
+	subsets := make([]Subset, 0)
+	l := solverLayout(s)
+	for i := range l.houses {
+		subsets = append(subsets, hiddenSubsetsInHouse(s, &l.houses[i], size)...)
+	}
+	return subsets
+}
+
+// SolveHiddenSubset removes the other candidates from the cells of the hidden
+// subsets of the size, house by house. It returns the applied subsets.
+func SolveHiddenSubset(s *Solver, size int) ([]Subset, bool, bool) {
+	if !strategyReady(s) {
+		return nil, false, false
+	}
+	applied := make([]Subset, 0)
+	updated := false
+	l := solverLayout(s)
+	for i := range l.houses {
+		for _, subset := range hiddenSubsetsInHouse(s, &l.houses[i], size) {
+			removed := false
+			for _, cell := range subset.Cells {
+				removed = s.eliminate(cell.Row, cell.Col, AllCandidates(s.Length).Minus(subset.Digits)) || removed
+			}
+			if removed {
+				applied = append(applied, subset)
+				updated = true
+			}
+		}
+	}
+	return applied, updated, isSolved(s)
+}
+
+// isSolved returns whether the sudoku has no empty cell
+func isSolved(s *Solver) bool {
+	for _, row := range s.Problem.Sudoku {
+		for _, value := range row {
+			if value == 0 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Naked Triple
+func SolveNakedTriple(s *Solver) (bool, bool) {
+	_, updated, solved := SolveNakedSubset(s, 3)
+	return updated, solved
+}
+
+// Naked Quad
+func SolveNakedQuad(s *Solver) (bool, bool) {
+	_, updated, solved := SolveNakedSubset(s, 4)
+	return updated, solved
+}
+
+// Hidden Pair
+func SolveHiddenPair(s *Solver) (bool, bool) {
+	_, updated, solved := SolveHiddenSubset(s, 2)
+	return updated, solved
+}
+
+// Hidden Triple
+func SolveHiddenTriple(s *Solver) (bool, bool) {
+	_, updated, solved := SolveHiddenSubset(s, 3)
+	return updated, solved
+}
+
+// Hidden Quad
+func SolveHiddenQuad(s *Solver) (bool, bool) {
+	_, updated, solved := SolveHiddenSubset(s, 4)
+	return updated, solved
+}
@@ -0,0 +1,172 @@
+package solver
+
+import (
+	"testing"
+)
+
+// emptySolver returns the 9x9 solver without values and all candidates
+func emptySolver() *Solver {
+	m := SudokuMatrix{Sudoku: make([][]int, 9)}
+	for r := range m.Sudoku {
+		m.Sudoku[r] = make([]int, 9)
+	}
+	s, _ := CheckSudoku(&m)
+	UpdateAllCandidates(s)
+	return s
+}
+
+func TestSubsets1(t *testing.T) {
+	count := 0
+	combinations(5, 3, func(indexes []int) bool {
+		count++
+		if (indexes[0] >= indexes[1]) || (indexes[1] >= indexes[2]) {
+			t.Errorf("indexes not increasing: %v", indexes)
+		}
+		return true
+	})
+	if count != 10 {
+		t.Errorf("expected 10 combinations, got %v", count)
+	}
+}
+
+func TestSubsets2(t *testing.T) {
+	// 1 and 2 only in [0, 0] and [0, 1] of row 0
+	s := emptySolver()
+	for c := 2; c < 9; c++ {
+		s.Candidates[0][c] = s.Candidates[0][c].Remove(1).Remove(2)
+	}
+
+	subsets := HiddenSubsets(s, 2)
+	if len(subsets) != 1 {
+		t.Fatalf("expected 1 hidden pair, got %v", subsets)
+	}
+	subset := subsets[0]
+	if (subset.Kind != HouseRow) || (subset.Index != 0) || (subset.Digits != NewCandidateSet(1, 2)) {
+		t.Errorf("unexpected hidden pair %v", subset)
+	}
+	if (len(subset.Cells) != 2) || (subset.Cells[0] != Cell{0, 0}) || (subset.Cells[1] != Cell{0, 1}) {
+		t.Errorf("unexpected cells %v", subset.Cells)
+	}
+
+	applied, updated, solved := SolveHiddenSubset(s, 2)
+	if (len(applied) != 1) || !updated || solved {
+		t.Fatalf("unexpected result %v, %v, %v", applied, updated, solved)
+	}
+	if (s.Candidates[0][0] != NewCandidateSet(1, 2)) || (s.Candidates[0][1] != NewCandidateSet(1, 2)) {
+		t.Errorf("unexpected candidates %v %v", s.Candidates[0][0], s.Candidates[0][1])
+	}
+	if updated, _ := SolveHiddenPair(s); updated {
+		t.Errorf("hidden pair applied twice")
+	}
+}
+
+func TestSubsets3(t *testing.T) {
+	// 3, 4 and 5 only in [2, 6], [3, 6] and [7, 6] of col 6, no cell has all three
+	s := emptySolver()
+	for r := 0; r < 9; r++ {
+		switch r {
+		case 2:
+			s.Candidates[r][6] = s.Candidates[r][6].Remove(5)
+		case 3:
+			s.Candidates[r][6] = s.Candidates[r][6].Remove(3)
+		case 7:
+			s.Candidates[r][6] = s.Candidates[r][6].Remove(4)
+		default:
+			s.Candidates[r][6] = s.Candidates[r][6].Remove(3).Remove(4).Remove(5)
+		}
+	}
+
+	if subsets := HiddenSubsets(s, 2); len(subsets) != 0 {
+		t.Errorf("unexpected hidden pairs %v", subsets)
+	}
+	applied, updated, _ := SolveHiddenSubset(s, 3)
+	if (len(applied) != 1) || !updated {
+		t.Fatalf("expected 1 hidden triple, got %v", applied)
+	}
+	if (applied[0].Kind != HouseCol) || (applied[0].Index != 6) || (applied[0].Digits != NewCandidateSet(3, 4, 5)) {
+		t.Errorf("unexpected hidden triple %v", applied[0])
+	}
+	expected := map[int]CandidateSet{2: NewCandidateSet(3, 4), 3: NewCandidateSet(4, 5), 7: NewCandidateSet(3, 5)}
+	for r, candidates := range expected {
+		if s.Candidates[r][6] != candidates {
+			t.Errorf("[%v, 6]: expected %v, got %v", r, candidates, s.Candidates[r][6])
+		}
+	}
+}
+
+func TestSubsets4(t *testing.T) {
+	// naked triple {1, 2}, {2, 3}, {1, 3} in row 4
+	s := emptySolver()
+	s.Candidates[4][0] = NewCandidateSet(1, 2)
+	s.Candidates[4][4] = NewCandidateSet(2, 3)
+	s.Candidates[4][8] = NewCandidateSet(1, 3)
+
+	if subsets := NakedSubsets(s, 2); len(subsets) != 0 {
+		t.Errorf("unexpected naked pairs %v", subsets)
+	}
+	applied, updated, solved := SolveNakedSubset(s, 3)
+	if (len(applied) != 1) || !updated || solved {
+		t.Fatalf("unexpected result %v, %v, %v", applied, updated, solved)
+	}
+	subset := applied[0]
+	if (subset.Kind != HouseRow) || (subset.Index != 4) || (subset.Digits != NewCandidateSet(1, 2, 3)) || (len(subset.Cells) != 3) {
+		t.Errorf("unexpected naked triple %v", subset)
+	}
+	for c := 0; c < 9; c++ {
+		if (c != 0) && (c != 4) && (c != 8) && (s.Candidates[4][c] != NewCandidateSet(4, 5, 6, 7, 8, 9)) {
+			t.Errorf("[4, %v]: unexpected candidates %v", c, s.Candidates[4][c])
+		}
+	}
+	if s.Candidates[3][0] != AllCandidates(9) {
+		t.Errorf("candidates removed outside the row: %v", s.Candidates[3][0])
+	}
+}
+
+func TestSubsets5(t *testing.T) {
+	// naked pair in block 4 only, the cells are in different rows and cols
+	s := emptySolver()
+	s.Candidates[3][3] = NewCandidateSet(7, 8)
+	s.Candidates[5][5] = NewCandidateSet(7, 8)
+
+	updated, _ := SolveNakedPair(s)
+	if !updated {
+		t.Fatalf("naked pair in block not applied")
+	}
+	for _, cell := range solverLayout(s).block(4, 4).Cells {
+		if (cell == Cell{3, 3}) || (cell == Cell{5, 5}) {
+			continue
+		}
+		if s.Candidates[cell.Row][cell.Col].Intersect(NewCandidateSet(7, 8)) != 0 {
+			t.Errorf("[%v, %v]: unexpected candidates %v", cell.Row, cell.Col, s.Candidates[cell.Row][cell.Col])
+		}
+	}
+	if s.Candidates[3][0] != AllCandidates(9) {
+		t.Errorf("candidates removed outside the block: %v", s.Candidates[3][0])
+	}
+}
+
+func TestSubsets6(t *testing.T) {
+	// naked quint in row 0 of 16x16
+	m := SudokuMatrix{Sudoku: make([][]int, 16)}
+	for r := range m.Sudoku {
+		m.Sudoku[r] = make([]int, 16)
+	}
+	s, _ := CheckSudoku(&m)
+	UpdateAllCandidates(s)
+	quint := NewCandidateSet(1, 2, 3, 4, 5)
+	for c := 0; c < 5; c++ {
+		s.Candidates[0][c*3] = quint.Remove(c + 1)
+	}
+
+	if updated, _ := SolveNakedSubsets(s); updated {
+		t.Errorf("unexpected naked subset up to quad")
+	}
+	if _, updated, _ := SolveNakedSubset(s, 5); !updated {
+		t.Fatalf("naked quint not applied")
+	}
+	for c := 0; c < 16; c++ {
+		if ((c%3 != 0) || (c > 12)) && (s.Candidates[0][c].Intersect(quint) != 0) {
+			t.Errorf("[0, %v]: unexpected candidates %v", c, s.Candidates[0][c])
+		}
+	}
+}
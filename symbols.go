@@ -0,0 +1,137 @@
+package solver
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// SymbolSet maps values to the symbols written in puzzles, the symbol of
+// value v is the v-th symbol of the set. Letters are matched case insensitive.
+type SymbolSet struct {
+	Name    string
+	symbols []rune
+	values  map[rune]int
+}
+
+var (
+	SymbolsDigits        = mustSymbolSet("1-9", "123456789")
+	SymbolsHex           = mustSymbolSet("0-F", "0123456789ABCDEF")
+	SymbolsLetters       = mustSymbolSet("A-Z", "ABCDEFGHIJKLMNOPQRSTUVWXYZ")
+	SymbolsDigitsLetters = mustSymbolSet("1-9A-Z", "123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ")
+)
+
+// blanks are symbols of empty cells unless they belong to the symbol set
+const blanks = ".0_"
+
+func NewSymbolSet(name string, symbols string) (*SymbolSet, error) {
+	ss := SymbolSet{Name: name, symbols: []rune(symbols), values: make(map[rune]int)}
+	for i, ch := range ss.symbols {
+		if unicode.IsSpace(ch) || strings.ContainsRune(".|_", ch) {
+			return nil, fmt.Errorf("ERROR: Invalid symbol %q", ch)
+		}
+		if _, ok := ss.values[ch]; ok {
+			return nil, fmt.Errorf("ERROR: Duplicate symbol %q", ch)
+		}
+		ss.values[ch] = i + 1
+	}
+	return &ss, nil
+}
+
+func mustSymbolSet(name string, symbols string) *SymbolSet {
+	ss, err := NewSymbolSet(name, symbols)
+	if err != nil {
+		panic(err)
+	}
+	return ss
+}
+
+// DefaultSymbolSet returns digits for sudoku up to 9x9 and digits followed by
+// letters for larger sudoku
+func DefaultSymbolSet(length int) *SymbolSet {
+	if length <= SymbolsDigits.Len() {
+		return SymbolsDigits
+	}
+	return SymbolsDigitsLetters
+}
+
+func (ss *SymbolSet) Len() int {
+	return len(ss.symbols)
+}
+
+// Fits reports whether the set has a symbol for every value of the sudoku
+func (ss *SymbolSet) Fits(length int) bool {
+	return length <= len(ss.symbols)
+}
+
+// Symbol returns the symbol of the value or '?' for values outside the set
+func (ss *SymbolSet) Symbol(value int) rune {
+	if (value < 1) || (value > len(ss.symbols)) {
+		return '?'
+	}
+	return ss.symbols[value-1]
+}
+
+// Value returns the value of the symbol
+func (ss *SymbolSet) Value(ch rune) (int, bool) {
+	if value, ok := ss.values[ch]; ok {
+		return value, true
+	}
+	if value, ok := ss.values[unicode.ToUpper(ch)]; ok {
+		return value, true
+	}
+	value, ok := ss.values[unicode.ToLower(ch)]
+	return value, ok
+}
+
+func (ss *SymbolSet) IsBlank(ch rune) bool {
+	if _, ok := ss.values[ch]; ok {
+		return false
+	}
+	return strings.ContainsRune(blanks, ch)
+}
+
+// accepts reports whether every non space symbol is a blank or a value of the sudoku
+func (ss *SymbolSet) accepts(text string, length int, extraBlanks string) bool {
+	for _, ch := range text {
+		if unicode.IsSpace(ch) || ss.IsBlank(ch) {
+			continue
+		}
+		if value, ok := ss.Value(ch); ok && (value <= length) {
+			continue
+		}
+		if strings.ContainsRune(extraBlanks, ch) {
+			continue
+		}
+		return false
+	}
+	return true
+}
+
+// DetectSymbolSet returns the conventional symbol set in which the text is a
+// sudoku with the side length. Hexadecimal symbols (0-F) are detected when
+// '0' is used together with '.' or '_' blanks in sudoku larger than 9x9,
+// otherwise '0', '.' and '_' are all blanks.
+func DetectSymbolSet(text string, length int) *SymbolSet {
+	return detectSymbolSet(text, length, "")
+}
+
+func detectSymbolSet(text string, length int, extraBlanks string) *SymbolSet {
+	sets := []*SymbolSet{SymbolsDigits, SymbolsDigitsLetters, SymbolsHex, SymbolsLetters}
+	if (length > 9) && strings.ContainsRune(text, '0') && strings.ContainsAny(text, "._") {
+		sets = []*SymbolSet{SymbolsHex, SymbolsDigits, SymbolsDigitsLetters, SymbolsLetters}
+	}
+	for _, ss := range sets {
+		if ss.Fits(length) && ss.accepts(text, length, extraBlanks) {
+			return ss
+		}
+	}
+	return DefaultSymbolSet(length)
+}
+
+func symbolsOrDefault(ss *SymbolSet, length int) *SymbolSet {
+	if ss == nil {
+		return DefaultSymbolSet(length)
+	}
+	return ss
+}
@@ -0,0 +1,65 @@
+package solver
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSymbolSet1(t *testing.T) {
+	tests := []struct {
+		text     string
+		length   int
+		expected *SymbolSet
+	}{
+		{"1.3.", 4, SymbolsDigits},
+		{"19.G" + strings.Repeat(".", 252), 16, SymbolsDigitsLetters},
+		{"0.F1" + strings.Repeat(".", 252), 16, SymbolsHex},
+		{"0AF1" + strings.Repeat("0", 252), 16, SymbolsDigitsLetters},
+		{"AP.." + strings.Repeat(".", 252), 16, SymbolsLetters},
+		{"1P.." + strings.Repeat(".", 621), 25, SymbolsDigitsLetters},
+		{"AY.." + strings.Repeat(".", 621), 25, SymbolsLetters},
+	}
+	for i, test := range tests {
+		ss := DetectSymbolSet(test.text, test.length)
+		if ss != test.expected {
+			t.Errorf("test %v: expected %v, got %v", i, test.expected.Name, ss.Name)
+		}
+	}
+}
+
+func TestSymbolSet2(t *testing.T) {
+	line := "0123456789ABCDEF" + strings.Repeat(".", 240)
+	m, err := ParseLine(line)
+	if err != nil {
+		t.Fatalf("error: %v\n", err)
+	}
+	if m.Sudoku[0][0] != 1 || m.Sudoku[0][15] != 16 {
+		t.Errorf("wrong values parsed: %v", m.Sudoku[0])
+	}
+	if FormatLineSymbols(m, SymbolsHex) != line {
+		t.Errorf("expected %v, got %v", line, FormatLineSymbols(m, SymbolsHex))
+	}
+	if !strings.HasPrefix(FormatLine(m), "123456789ABCDEFG") {
+		t.Errorf("wrong default symbols: %v", FormatLine(m))
+	}
+
+	ss, err := NewSymbolSet("animals", "CDMX")
+	if err != nil {
+		t.Fatalf("error: %v\n", err)
+	}
+	m, err = ParseLineSymbols("c..."+"..d."+".m.."+"...x", ss)
+	if err != nil {
+		t.Fatalf("error: %v\n", err)
+	}
+	s, _ := CheckSudoku(m)
+	var sb strings.Builder
+	Fprint(&sb, s, CompactRenderer{Options: RenderOptions{Symbols: ss}})
+	expected := "C .   . . \n. .   D . \n\n. M   . . \n. .   . X \n"
+	if sb.String() != expected {
+		t.Errorf("expected %q, got %q", expected, sb.String())
+	}
+
+	if _, err = NewSymbolSet("duplicate", "ABCA"); err == nil {
+		t.Errorf("expected error on duplicate symbol")
+	}
+}
@@ -1,12 +1,23 @@
 package solver
 
-type SudokuMatrix = struct {
+type SudokuMatrix struct {
 	Sudoku [][]int
 }
 
-type Solver = struct {
+type Solver struct {
 	Problem    SudokuMatrix
-	Candidates [][][]int
+	Candidates [][]CandidateSet // candidates of empty cells, see CandidateSlices for the slice view
 	Length     int
-	Dim        int
+	Dim        int      // block side of square blocks, 0 for rectangular blocks
+	BoxHeight  int      // rows of a block
+	BoxWidth   int      // cols of a block
+	Givens     [][]bool // cells with initial values, the other non-zero values were solved
+	validated  bool     // set by CheckSudoku
+	observer   Observer // set by SolveOptions.Observer while solving
+	strategy   string   // strategy of the events
+}
+
+type Cell struct {
+	Row int
+	Col int
 }
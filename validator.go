@@ -3,60 +3,247 @@ package solver
 import (
 	"fmt"
 	"math"
+	"strings"
 )
 
+// MinLength is the smallest supported sudoku (4x4), MaxLength is the largest
+const MinLength = 4
+
+type ViolationKind int
+
+const (
+	ViolationRow ViolationKind = iota + 1
+	ViolationCol
+	ViolationBlock
+	ViolationRange     // value outside 0..Length
+	ViolationNotSquare // row length differs from the number of rows
+	ViolationDimension // no block size for the number of rows or outside MinLength..MaxLength
+)
+
+func (k ViolationKind) String() string {
+	switch k {
+	case ViolationRow:
+		return "row"
+	case ViolationCol:
+		return "col"
+	case ViolationBlock:
+		return "block"
+	case ViolationRange:
+		return "range"
+	case ViolationNotSquare:
+		return "not square"
+	case ViolationDimension:
+		return "dimension"
+	}
+	return fmt.Sprintf("ViolationKind(%d)", int(k))
+}
+
+// Violation is a rule of the sudoku matrix that is broken. Unit is the index
+// of the row, col or block (blocks are numbered row by row), the row for
+// range and not square violations and the number of rows for dimension
+// violations. Cells are the pairs of clashing cells with the same Value, for
+// range violations the pair holds the cell with the value out of range twice.
+type Violation struct {
+	Kind  ViolationKind
+	Unit  int
+	Value int
+	Cells [][2]Cell
+}
+
+func (v *Violation) Error() string {
+	switch v.Kind {
+	case ViolationRow, ViolationCol:
+		return fmt.Sprintf("ERROR: Same value in %v %v", v.Kind, v.Unit)
+	case ViolationBlock:
+		return fmt.Sprintf("ERROR: Same value in block in pos [%v, %v]", v.Cells[0][1].Row, v.Cells[0][1].Col)
+	case ViolationRange:
+		return fmt.Sprintf("ERROR: Value %v out of range in pos [%v, %v]", v.Value, v.Cells[0][0].Row, v.Cells[0][0].Col)
+	case ViolationNotSquare:
+		return "ERROR: Sudoku matrix is not square"
+	case ViolationDimension:
+		if v.Unit == 0 {
+			return "ERROR: Sudoku matrix is empty"
+		}
+	}
+	return "ERROR: Invalid sudoku matrix"
+}
+
+// ValidationError lists all violations of a sudoku matrix, every violation
+// is reachable with errors.As
+type ValidationError struct {
+	Violations []*Violation
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		messages[i] = v.Error()
+	}
+	return strings.Join(messages, "\n")
+}
+
+func (e *ValidationError) Unwrap() []error {
+	errs := make([]error, len(e.Violations))
+	for i, v := range e.Violations {
+		errs[i] = v
+	}
+	return errs
+}
+
+func newDuplicate(kind ViolationKind, unit int, value int, row1 int, col1 int, row2 int, col2 int) *Violation {
+	return &Violation{Kind: kind, Unit: unit, Value: value, Cells: [][2]Cell{{{Row: row1, Col: col1}, {Row: row2, Col: col2}}}}
+}
+
+// BoxSize returns the block size of the sudoku with the side length. Blocks
+// are square when the length is a square, otherwise the block is the widest
+// rectangle with more cols than rows (6x6 is 2x3, 8x8 is 2x4, 12x12 is 3x4).
+func BoxSize(length int) (height int, width int, ok bool) {
+	if length < MinLength {
+		return 0, 0, false
+	}
+	for height = int(math.Sqrt(float64(length))); height >= 2; height-- {
+		if length%height == 0 {
+			return height, length / height, true
+		}
+	}
+	return 0, 0, false
+}
+
+func validBoxSize(length int, boxHeight int, boxWidth int) bool {
+	return (length >= MinLength) && (length <= MaxLength) && (boxHeight >= 2) && (boxWidth >= 2) && (boxHeight*boxWidth == length)
+}
+
+// Validate checks the whole sudoku matrix and returns a *ValidationError with
+// every violation, or nil when the matrix is valid. The block size is BoxSize.
+func Validate(m *SudokuMatrix) error {
+	boxHeight, boxWidth, _ := BoxSize(len(m.Sudoku))
+	return ValidateBoxes(m, boxHeight, boxWidth)
+}
+
+// ValidateBoxes is Validate with the block size boxHeight x boxWidth
+func ValidateBoxes(m *SudokuMatrix, boxHeight int, boxWidth int) error {
+	violations := make([]*Violation, 0)
+	length := len(m.Sudoku)
+	if !validBoxSize(length, boxHeight, boxWidth) {
+		violations = append(violations, &Violation{Kind: ViolationDimension, Unit: length})
+	}
+	for r, row := range m.Sudoku {
+		if len(row) != length {
+			violations = append(violations, &Violation{Kind: ViolationNotSquare, Unit: r})
+		}
+	}
+	if len(violations) > 0 {
+		return &ValidationError{Violations: violations}
+	}
+
+	for r, row := range m.Sudoku {
+		for c, value := range row {
+			if (value < 0) || (value > length) {
+				violations = append(violations, &Violation{Kind: ViolationRange, Unit: r, Value: value, Cells: [][2]Cell{{{Row: r, Col: c}, {Row: r, Col: c}}}})
+			}
+		}
+	}
+
+	houses := layoutOf(length, boxHeight, boxWidth).houses
+	for k, kind := range []ViolationKind{ViolationRow, ViolationCol, ViolationBlock} {
+		for unit := 0; unit < length; unit++ {
+			cells := houses[k*length+unit].Cells
+			byValue := make(map[int]*Violation)
+			values := make([]int, 0)
+			for i, cell1 := range cells {
+				value := m.Sudoku[cell1.Row][cell1.Col]
+				if value == 0 {
+					continue
+				}
+				for _, cell2 := range cells[i+1:] {
+					if m.Sudoku[cell2.Row][cell2.Col] != value {
+						continue
+					}
+					v, ok := byValue[value]
+					if !ok {
+						v = &Violation{Kind: kind, Unit: unit, Value: value}
+						byValue[value] = v
+						values = append(values, value)
+					}
+					v.Cells = append(v.Cells, [2]Cell{cell1, cell2})
+				}
+			}
+			for _, value := range values {
+				violations = append(violations, byValue[value])
+			}
+		}
+	}
+
+	if len(violations) > 0 {
+		return &ValidationError{Violations: violations}
+	}
+	return nil
+}
+
+// CheckSudoku validates the sudoku matrix and returns the solver for it, the
+// block size is BoxSize
 func CheckSudoku(m *SudokuMatrix) (*Solver, error) {
+	boxHeight, boxWidth, _ := BoxSize(len(m.Sudoku))
+	return CheckSudokuBoxes(m, boxHeight, boxWidth)
+}
+
+// CheckSudokuBoxes is CheckSudoku with the block size boxHeight x boxWidth,
+// e.g. 3x2 blocks of 6x6 sudoku
+func CheckSudokuBoxes(m *SudokuMatrix, boxHeight int, boxWidth int) (*Solver, error) {
 	solver := Solver{}
 	length := len((*m).Sudoku)
-	fDim := math.Sqrt(float64(length))
-	if math.Floor(fDim) != fDim {
-		return &solver, fmt.Errorf("ERROR: Invalid sudoku matrix")
+	if !validBoxSize(length, boxHeight, boxWidth) {
+		return &solver, &Violation{Kind: ViolationDimension, Unit: length}
 	}
-	dim := int(fDim)
 	solver.Length = length
-	solver.Dim = int(dim)
+	if boxHeight == boxWidth {
+		solver.Dim = boxHeight
+	}
+	solver.BoxHeight = boxHeight
+	solver.BoxWidth = boxWidth
+	l := layoutOf(length, boxHeight, boxWidth)
 	solver.Problem = *m
+	solver.Givens = make([][]bool, length)
 
 	for rowIndex, row := range (*m).Sudoku {
-
 		if len(row) != length { // check whether the matrix is square
-			return &solver, fmt.Errorf("ERROR: Sudoku matrix is not square")
+			return &solver, &Violation{Kind: ViolationNotSquare, Unit: rowIndex}
 		}
+	}
+
+	for rowIndex, row := range (*m).Sudoku {
+		solver.Givens[rowIndex] = make([]bool, length)
 
 		for colIndex, search := range row {
 			if search == 0 {
 				continue
 			}
+			if (search < 0) || (search > length) {
+				return &solver, &Violation{Kind: ViolationRange, Unit: rowIndex, Value: search, Cells: [][2]Cell{{{Row: rowIndex, Col: colIndex}, {Row: rowIndex, Col: colIndex}}}}
+			}
+			solver.Givens[rowIndex][colIndex] = true
 
 			for c, colValue := range row { // search for duplicates in row
 				if (c != colIndex) && (search == colValue) {
-					return &solver, fmt.Errorf("ERROR: Same value in row %v", rowIndex)
+					return &solver, newDuplicate(ViolationRow, rowIndex, search, rowIndex, colIndex, rowIndex, c)
 				}
 			}
 
 			for r, rowValue := range m.Sudoku { // search for duplicates in cols
 				if (r != rowIndex) && (search == rowValue[colIndex]) {
-					return &solver, fmt.Errorf("ERROR: Same value in col %v", colIndex)
+					return &solver, newDuplicate(ViolationCol, colIndex, search, rowIndex, colIndex, r, colIndex)
 				}
 			}
 
-			startRow := (rowIndex / dim) * dim
-			endRow := startRow + dim
-			startCol := (colIndex / dim) * dim
-			endCol := startCol + dim
-
-			for r := startRow; r < endRow; r++ { // search for duplicates in block
-				if r == rowIndex {
-					continue
-				}
-				for c := startCol; c < endCol; c++ {
-					if (c != colIndex) && (search == (*m).Sudoku[r][c]) {
-						return &solver, fmt.Errorf("ERROR: Same value in block in pos [%v, %v]", r, c)
-					}
+			block := l.block(rowIndex, colIndex)
+			for _, cell := range block.Cells { // search for duplicates in block
+				if (cell.Row != rowIndex) && (cell.Col != colIndex) && (search == (*m).Sudoku[cell.Row][cell.Col]) {
+					return &solver, newDuplicate(ViolationBlock, block.Index, search, rowIndex, colIndex, cell.Row, cell.Col)
 				}
 			}
 		}
 	}
 
+	solver.validated = true
 	return &solver, nil
 }
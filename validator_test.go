@@ -1,6 +1,7 @@
 package solver
 
 import (
+	"errors"
 	"fmt"
 	"testing"
 )
@@ -28,7 +29,10 @@ func TestSudokuValidator1(t *testing.T) {
 		fmt.Println("initial values ")
 	}
 	Print(v)
-	solved := Solve(v)
+	solved, err := Solve(v)
+	if err != nil {
+		t.Errorf("error: %v\n", err)
+	}
 
 	fmt.Printf("sudoku solved: %v\n", solved)
 	Print(v)
@@ -247,3 +251,240 @@ func TestSudokuValidator8(t *testing.T) {
 		t.Errorf(errorStr)
 	}()
 }
+
+func TestSudokuValidator9(t *testing.T) {
+	s := SudokuMatrix{
+		Sudoku: [][]int{
+			{1, 0, +0, 1},
+			{0, 1, +0, 0},
+
+			{0, 0, +2, 0},
+			{0, 0, +0, 2},
+		}}
+
+	err := Validate(&s)
+	fmt.Println(err)
+
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+	kinds := make(map[ViolationKind]int)
+	for _, v := range verr.Violations {
+		kinds[v.Kind]++
+	}
+	if kinds[ViolationRow] != 1 || kinds[ViolationCol] != 0 || kinds[ViolationBlock] != 2 {
+		t.Errorf("wrong violations: %v", kinds)
+	}
+
+	var v *Violation
+	if !errors.As(err, &v) || v.Kind != ViolationRow || v.Unit != 0 || v.Value != 1 {
+		t.Errorf("wrong first violation: %+v", v)
+	}
+	if v.Cells[0] != [2]Cell{{Row: 0, Col: 0}, {Row: 0, Col: 3}} {
+		t.Errorf("wrong cells: %v", v.Cells)
+	}
+
+	_, err = CheckSudoku(&s)
+	if !errors.As(err, &v) || v.Kind != ViolationRow {
+		t.Errorf("expected row violation, got %v", err)
+	}
+}
+
+func TestSudokuValidator10(t *testing.T) {
+	s := SudokuMatrix{
+		Sudoku: [][]int{
+			{1, 0, 0},
+			{0, 0, 0},
+			{0, 0},
+		}}
+
+	err := Validate(&s)
+	fmt.Println(err)
+
+	var verr *ValidationError
+	if !errors.As(err, &verr) || len(verr.Violations) != 2 {
+		t.Fatalf("expected dimension and not square violations, got %v", err)
+	}
+	if verr.Violations[0].Kind != ViolationDimension || verr.Violations[1].Kind != ViolationNotSquare || verr.Violations[1].Unit != 2 {
+		t.Errorf("wrong violations: %v", err)
+	}
+
+	if err = Validate(&SudokuMatrix{Sudoku: [][]int{{1, 0, 0, 0}, {0, 0, 0, 0}, {0, 0, 0, 0}, {0, 0, 0, 4}}}); err != nil {
+		t.Errorf("error: %v\n", err)
+	}
+}
+
+func TestSudokuValidator11(t *testing.T) {
+	tests := []struct {
+		sudoku [][]int
+		kind   ViolationKind
+	}{
+		{nil, ViolationDimension},
+		{[][]int{}, ViolationDimension},
+		{[][]int{{1}}, ViolationDimension},
+		{[][]int{{1, 0, 0, 0}, {0, 0, 0, 0}, {0, 0, 5, 0}, {0, 0, 0, 0}}, ViolationRange},
+		{[][]int{{1, 0, 0, 0}, {0, -3, 0, 0}, {0, 0, 0, 0}, {0, 0, 0, 0}}, ViolationRange},
+		{[][]int{{1, 0, 0, 0}, {0, 0, 0, 0}, {0, 0, 0, 0}, {0, 0, 0}}, ViolationNotSquare},
+		{[][]int{{1, 0, 0}, {0, 0, 0, 0}, {0, 0, 0, 0}, {0, 0, 0, 0}}, ViolationNotSquare},
+	}
+	for i, test := range tests {
+		s := SudokuMatrix{Sudoku: test.sudoku}
+		_, err := CheckSudoku(&s)
+		fmt.Println(err)
+		var v *Violation
+		if !errors.As(err, &v) || v.Kind != test.kind {
+			t.Errorf("test %v: expected %v violation, got %v", i, test.kind, err)
+		}
+		err = Validate(&s)
+		if !errors.As(err, &v) || v.Kind != test.kind {
+			t.Errorf("test %v: expected %v violation, got %v", i, test.kind, err)
+		}
+	}
+}
+
+func TestSudokuValidator12(t *testing.T) {
+	s := SudokuMatrix{
+		Sudoku: [][]int{
+			{1, 2, +0, 0},
+			{0, 0, +0, 4},
+
+			{0, 0, +0, 0},
+			{0, 0, +12, 0},
+		}}
+
+	v, _ := CheckSudoku(&s)
+	solved, err := Solve(v)
+	if solved || !errors.Is(err, ErrNotValidated) {
+		t.Errorf("expected %v, got %v", ErrNotValidated, err)
+	}
+}
+
+func TestSudokuValidator13(t *testing.T) {
+	sizes := []struct {
+		length, height, width int
+		ok                    bool
+	}{
+		{4, 2, 2, true},
+		{6, 2, 3, true},
+		{8, 2, 4, true},
+		{9, 3, 3, true},
+		{12, 3, 4, true},
+		{16, 4, 4, true},
+		{7, 0, 0, false},
+		{3, 0, 0, false},
+	}
+	for _, size := range sizes {
+		height, width, ok := BoxSize(size.length)
+		if (height != size.height) || (width != size.width) || (ok != size.ok) {
+			t.Errorf("length %v: expected %vx%v %v, got %vx%v %v", size.length, size.height, size.width, size.ok, height, width, ok)
+		}
+	}
+
+	s := SudokuMatrix{
+		Sudoku: [][]int{
+			{1, 2, 3, 4, 5, 6},
+			{4, 5, 6, 1, 2, 3},
+
+			{2, 3, 1, 5, 6, 4},
+			{5, 6, 4, 2, 3, 1},
+
+			{3, 1, 2, 6, 4, 5},
+			{6, 4, 5, 3, 1, 2},
+		}}
+	v, err := CheckSudoku(&s)
+	if err != nil || v.BoxHeight != 2 || v.BoxWidth != 3 || v.Dim != 0 {
+		t.Fatalf("expected valid 2x3 blocks, got %v %+v", err, v)
+	}
+	if err = Validate(&s); err != nil {
+		t.Errorf("expected valid sudoku, got %v", err)
+	}
+
+	// the same matrix has duplicates in 3x2 blocks
+	var violation *Violation
+	_, err = CheckSudokuBoxes(&s, 3, 2)
+	fmt.Println(err)
+	if !errors.As(err, &violation) || violation.Kind != ViolationBlock {
+		t.Errorf("expected block violation, got %v", err)
+	}
+	err = ValidateBoxes(&s, 3, 2)
+	if !errors.As(err, &violation) || violation.Kind != ViolationBlock || violation.Unit != 0 {
+		t.Errorf("expected block violation, got %v", err)
+	}
+	if _, err = CheckSudokuBoxes(&s, 2, 2); !errors.As(err, &violation) || violation.Kind != ViolationDimension {
+		t.Errorf("expected dimension violation, got %v", err)
+	}
+}
+
+func TestSudokuValidator14(t *testing.T) {
+	// every entry point returns ErrNotValidated, none of them panics
+	m := SudokuMatrix{Sudoku: [][]int{{1, 2, 0, 0}, {0, 0, 0, 4}, {0, 0, 0, 0}, {0, 0, 12, 0}}}
+	v, _ := CheckSudoku(&m)
+
+	calls := map[string]func() error{
+		"UpdateAllCandidates": func() error { return UpdateAllCandidates(v) },
+		"SolveDepthFirstSearch": func() error {
+			_, err := SolveDepthFirstSearch(v, 0, 0, 1)
+			return err
+		},
+		"SolveDLX": func() error {
+			_, err := SolveDLX(v)
+			return err
+		},
+		"EnumerateDLX": func() error {
+			_, err := EnumerateDLX(v, nil)
+			return err
+		},
+		"EnumerateSolutions": func() error {
+			_, err := EnumerateSolutions(v, func(*SudokuMatrix) bool { return true })
+			return err
+		},
+		"CountSolutions": func() error {
+			_, _, err := CountSolutions(v, 0)
+			return err
+		},
+	}
+	for _, backend := range []Backend{BackendStrategies, BackendDLX, BackendDepthFirstSearch} {
+		backend := backend
+		calls[fmt.Sprintf("backend %v", backend)] = func() error {
+			_, err := SolveWithOptions(v, SolveOptions{Backend: backend})
+			return err
+		}
+	}
+	for name, call := range calls {
+		if err := call(); !errors.Is(err, ErrNotValidated) {
+			t.Errorf("%v: expected %v, got %v", name, ErrNotValidated, err)
+		}
+	}
+	if m.Sudoku[3][2] != 12 {
+		t.Errorf("unvalidated sudoku changed")
+	}
+
+	// the strategies change nothing
+	strategies := map[string]func() bool{
+		"SolveNakedSingle":  func() bool { u, sv := SolveNakedSingle(v); return u || sv },
+		"SolveHiddenSingle": func() bool { u, sv := SolveHiddenSingle(v); return u || sv },
+		"SolveNakedPair":    func() bool { u, sv := SolveNakedPair(v); return u || sv },
+		"SolvePointingPair": func() bool { u, sv := SolvePointingPair(v); return u || sv },
+		"SolveNakedSubsets": func() bool { u, sv := SolveNakedSubsets(v); return u || sv },
+		"SolveNakedSubset": func() bool {
+			applied, u, sv := SolveNakedSubset(v, 3)
+			return (len(applied) > 0) || u || sv
+		},
+		"SolveHiddenSubset": func() bool {
+			applied, u, sv := SolveHiddenSubset(v, 2)
+			return (len(applied) > 0) || u || sv
+		},
+		"NakedSubsets":     func() bool { return len(NakedSubsets(v, 2)) > 0 },
+		"HiddenSubsets":    func() bool { return len(HiddenSubsets(v, 2)) > 0 },
+		"UpdateCandidates": func() bool { _, u, p := UpdateCandidates(v, 0, 2, 3); return u || p },
+	}
+	for name, call := range strategies {
+		if call() {
+			t.Errorf("%v: unvalidated sudoku updated", name)
+		}
+	}
+	if (v.Candidates != nil) || (m.Sudoku[0][2] != 0) {
+		t.Errorf("unvalidated sudoku changed")
+	}
+}